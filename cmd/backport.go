@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+	"gitrel/semver"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	ToFlag               []string
+	BackportContinueFlag bool
+	BackportAbortFlag    bool
+)
+
+var backportCmd = &cobra.Command{
+	Use:   "backport <commit>... --to <version>[,<version>...]",
+	Short: "Cherry-pick commits onto one or more release branches",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runBackportCmd(args, ToFlag, BackportContinueFlag, BackportAbortFlag, ctx)
+	},
+}
+
+func init() {
+	backportCmd.Flags().StringSliceVar(&ToFlag, "to", []string{}, "Version prefixes of the releases to backport to, e.g. 1.1,1.0")
+	backportCmd.Flags().BoolVar(&BackportContinueFlag, "continue", false, "Continue a backport after resolving a conflict")
+	backportCmd.Flags().BoolVar(&BackportAbortFlag, "abort", false, "Abort a backport stopped by a conflict")
+	backportCmd.MarkFlagsMutuallyExclusive("continue", "abort")
+}
+
+func runBackportCmd(args []string, to []string, cont bool, abort bool, ctx interfaces.GitRelContext) error {
+	var err error
+	switch {
+	case cont || abort:
+		if len(args) > 0 || len(to) > 0 {
+			err = usageError("--continue and --abort do not take commits or --to")
+		} else if cont {
+			err = git.ContinueBackport(ctx)
+		} else {
+			err = git.AbortBackport(ctx)
+		}
+	case len(args) == 0:
+		err = usageError("please specify at least one commit to backport")
+	case len(to) == 0:
+		err = usageError("please specify the releases to backport to with --to, e.g. --to 1.1,1.0")
+	default:
+		err = git.BackportCommits(args, to, ctx)
+	}
+
+	return emitResult("backport", semver.Version{}, err, ctx)
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"gitrel/gitrel_test"
+	"testing"
+)
+
+func TestRunBackportCmd_CherryPicksOntoEachTargetAndPushes(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Commits = map[string]string{"HEAD": "abc1234"}
+
+	// Act
+	runBackportCmd([]string{"HEAD", "def5678"}, []string{"1.1", "1.0"}, false, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCheckoutBranch("release/1.1.1"),
+		gitrel_test.EffectCherryPick("abc1234"),
+		gitrel_test.EffectCherryPick("def5678"),
+		gitrel_test.EffectPushBranch("origin", "release/1.1.1"),
+		gitrel_test.EffectCheckoutBranch("release/1.0.2"),
+		gitrel_test.EffectCherryPick("abc1234"),
+		gitrel_test.EffectCherryPick("def5678"),
+		gitrel_test.EffectPushBranch("origin", "release/1.0.2"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Backporting 2 commit(s) to release/1.1.1...",
+		"Pushing release/1.1.1 to origin...",
+		"Pushed!",
+		"Backporting 2 commit(s) to release/1.0.2...",
+		"Pushing release/1.0.2 to origin...",
+		"Pushed!",
+		"Switched back to branch: main",
+		"Backport results:",
+		" - release/1.1.1: backported",
+		" - release/1.0.2: backported",
+	)
+}
+
+func TestRunBackportCmd_StopsAtConflictAndSavesState(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.ConflictingBranches = []string{"release/1.0.2"}
+
+	// Act
+	runBackportCmd([]string{"abc1234"}, []string{"1.1", "1.0"}, false, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCheckoutBranch("release/1.1.1"),
+		gitrel_test.EffectCherryPick("abc1234"),
+		gitrel_test.EffectPushBranch("origin", "release/1.1.1"),
+		gitrel_test.EffectCheckoutBranch("release/1.0.2"),
+		gitrel_test.EffectCherryPick("abc1234"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Backporting 1 commit(s) to release/1.1.1...",
+		"Pushing release/1.1.1 to origin...",
+		"Pushed!",
+		"Backporting 1 commit(s) to release/1.0.2...",
+		"error cherry-picking abc1234 onto release/1.0.2: CONFLICT cherry-picking abc1234 onto release/1.0.2",
+		"resolve the conflicts, then run 'gitrel backport --continue', or 'gitrel backport --abort' to stop",
+	)
+	if ctx.GitContext.State["backport"] == nil {
+		t.Fatal("expected backport state to be saved")
+	}
+}
+
+func TestRunBackportCmd_ContinueFinishesRemainingTargets(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.ConflictingBranches = []string{"release/1.1.1"}
+	runBackportCmd([]string{"abc1234", "def5678"}, []string{"1.1", "1.0"}, false, false, ctx)
+
+	ctx.GitContext.ConflictingBranches = []string{}
+	ctx.GitContext.SideEffects = []gitrel_test.TestGitSideEffect{}
+	ctx.OutputContext.Output = ""
+
+	// Act
+	runBackportCmd([]string{}, []string{}, true, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectContinueCherryPick(),
+		gitrel_test.EffectCherryPick("def5678"),
+		gitrel_test.EffectPushBranch("origin", "release/1.1.1"),
+		gitrel_test.EffectCheckoutBranch("release/1.0.2"),
+		gitrel_test.EffectCherryPick("abc1234"),
+		gitrel_test.EffectCherryPick("def5678"),
+		gitrel_test.EffectPushBranch("origin", "release/1.0.2"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Continuing backport to release/1.1.1...",
+		"Pushing release/1.1.1 to origin...",
+		"Pushed!",
+		"Backporting 2 commit(s) to release/1.0.2...",
+		"Pushing release/1.0.2 to origin...",
+		"Pushed!",
+		"Switched back to branch: main",
+		"Backport results:",
+		" - release/1.1.1: backported",
+		" - release/1.0.2: backported",
+	)
+	if ctx.GitContext.State["backport"] != nil {
+		t.Fatal("expected backport state to be cleared")
+	}
+}
+
+func TestRunBackportCmd_AbortRestoresOriginalBranch(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.ConflictingBranches = []string{"release/1.0.2"}
+	runBackportCmd([]string{"abc1234"}, []string{"1.1", "1.0"}, false, false, ctx)
+
+	ctx.GitContext.SideEffects = []gitrel_test.TestGitSideEffect{}
+	ctx.OutputContext.Output = ""
+
+	// Act
+	runBackportCmd([]string{}, []string{}, false, true, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectAbortCherryPick(),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Aborted backport to release/1.0.2",
+		"Switched back to branch: main",
+		"Backport results:",
+		" - release/1.1.1: backported",
+	)
+	if ctx.GitContext.State["backport"] != nil {
+		t.Fatal("expected backport state to be cleared")
+	}
+}
+
+func TestRunBackportCmd_PrintsErrorWhenNoBackportInProgress(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+
+	// Act
+	runBackportCmd([]string{}, []string{}, true, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines("no backport in progress")
+}
+
+func TestRunBackportCmd_PrintsErrorWhenTargetNotFound(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+
+	// Act
+	runBackportCmd([]string{"abc1234"}, []string{"3.0"}, false, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines("no release branches found matching prefix: 3.0")
+}
+
+func TestRunBackportCmd_PrintsErrorWithoutTargets(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+
+	// Act
+	runBackportCmd([]string{"abc1234"}, []string{}, false, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines("please specify the releases to backport to with --to, e.g. --to 1.1,1.0")
+}
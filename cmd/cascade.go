@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+	"gitrel/semver"
+
+	"github.com/spf13/cobra"
+)
+
+var cascadeCmd = &cobra.Command{
+	Use:   "cascade <from-version>",
+	Short: "Merge a release forward into each newer release line and the default branch",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runCascadeCmd(args, ctx)
+	},
+}
+
+func runCascadeCmd(args []string, ctx interfaces.GitRelContext) error {
+	err := git.CascadeVersion(args[0], ctx)
+	return emitResult("cascade", semver.Version{}, err, ctx)
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"gitrel/gitrel_test"
+	"testing"
+)
+
+func TestRunCascadeCmd_MergesForwardThroughEachReleaseLine(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"feature/x",
+		"release/1.1.0",
+		"release/1.1.1",
+		"release/1.2.0",
+		"release/1.2.1",
+		"release/2.0.0",
+		"remotes/origin/release/2.0.1",
+	}
+	ctx.GitContext.CurrentBranch = "feature/x"
+
+	// Act
+	runCascadeCmd([]string{"1.1.1"}, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCheckoutBranch("release/1.2.1"),
+		gitrel_test.EffectMergeBranch("release/1.1.1"),
+		gitrel_test.EffectPushBranch("origin", "release/1.2.1"),
+		gitrel_test.EffectCreateBranchAt("release/2.0.1", "remotes/origin/release/2.0.1"),
+		gitrel_test.EffectCheckoutBranch("release/2.0.1"),
+		gitrel_test.EffectMergeBranch("release/1.2.1"),
+		gitrel_test.EffectPushBranch("origin", "release/2.0.1"),
+		gitrel_test.EffectCheckoutBranch("main"),
+		gitrel_test.EffectMergeBranch("release/2.0.1"),
+		gitrel_test.EffectPushBranch("origin", "main"),
+		gitrel_test.EffectCheckoutBranch("feature/x"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Cascading 1.1.1 -> 1.2.1 -> 2.0.1 -> main",
+		"[1/3] Merging release/1.1.1 into release/1.2.1...",
+		"Pushing release/1.2.1 to origin...",
+		"Pushed!",
+		"[2/3] Merging release/1.2.1 into release/2.0.1...",
+		"Pushing release/2.0.1 to origin...",
+		"Pushed!",
+		"[3/3] Merging release/2.0.1 into main...",
+		"Pushing main to origin...",
+		"Pushed!",
+		"Switched back to branch: feature/x",
+	)
+}
+
+func TestRunCascadeCmd_IncludesNewerPatchOnSameLine(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.1.0",
+		"release/1.1.1",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runCascadeCmd([]string{"1.1.0"}, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCheckoutBranch("release/1.1.1"),
+		gitrel_test.EffectMergeBranch("release/1.1.0"),
+		gitrel_test.EffectPushBranch("origin", "release/1.1.1"),
+		gitrel_test.EffectCheckoutBranch("main"),
+		gitrel_test.EffectMergeBranch("release/1.1.1"),
+		gitrel_test.EffectPushBranch("origin", "main"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+}
+
+func TestRunCascadeCmd_StopsAtFirstConflict(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.1.1",
+		"release/1.2.0",
+		"release/2.0.0",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+	ctx.GitContext.ConflictingBranches = []string{"release/2.0.0"}
+
+	// Act
+	runCascadeCmd([]string{"1.1"}, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCheckoutBranch("release/1.2.0"),
+		gitrel_test.EffectMergeBranch("release/1.1.1"),
+		gitrel_test.EffectPushBranch("origin", "release/1.2.0"),
+		gitrel_test.EffectCheckoutBranch("release/2.0.0"),
+		gitrel_test.EffectMergeBranch("release/1.2.0"),
+		gitrel_test.EffectAbortMerge(),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Cascading 1.1.1 -> 1.2.0 -> 2.0.0 -> main",
+		"[1/3] Merging release/1.1.1 into release/1.2.0...",
+		"Pushing release/1.2.0 to origin...",
+		"Pushed!",
+		"[2/3] Merging release/1.2.0 into release/2.0.0...",
+		"Switched back to branch: main",
+		"cascade stopped at hop 2 of 3, merging release/1.2.0 into release/2.0.0: CONFLICT merging release/1.2.0 into release/2.0.0",
+	)
+}
+
+func TestRunCascadeCmd_PrintsErrorWhenUncommittedChanges(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.HasUncommittedChangesFl = true
+
+	// Act
+	runCascadeCmd([]string{"1.0.0"}, ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"you have uncommitted changes. please commit or stash them before cascading",
+	)
+}
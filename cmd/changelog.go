@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+	"gitrel/semver"
+
+	"github.com/spf13/cobra"
+)
+
+var WriteFlag string
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog [<from>] [<to>]",
+	Short: "Show the changes between two releases, by default those in the latest release",
+	Args:  cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runChangelogCmd(args, WriteFlag, ctx)
+	},
+}
+
+func init() {
+	changelogCmd.Flags().StringVar(&WriteFlag, "write", "", "Add the changes as a section of this Keep a Changelog file, e.g. CHANGELOG.md")
+}
+
+func runChangelogCmd(args []string, write string, ctx interfaces.GitRelContext) error {
+	from, to := "", ""
+	if len(args) > 0 {
+		from = args[0]
+	}
+
+	if len(args) > 1 {
+		to = args[1]
+	}
+
+	changelog, err := git.GetChangelog(from, to, ctx)
+	if err == nil && write != "" {
+		err = git.WriteChangelog(write, changelog)
+	}
+
+	if err != nil {
+		return emitResult("changelog", semver.Version{}, err, ctx)
+	}
+
+	ctx.Output().Emit(changelog)
+	if write != "" {
+		ctx.Output().Printf("Updated %s with %s\n", write, changelog.Version)
+	}
+
+	return nil
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"gitrel/gitrel_test"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// changelogTestContext has releases 1.0.0, 1.1.0 and 1.2.0 on main, with
+// commits between each
+func changelogTestContext(t *testing.T) *gitrel_test.TestGitRelContext {
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.0.0",
+		"release/1.1.0",
+		"release/1.2.0",
+	}
+	ctx.GitContext.Commits = map[string]string{
+		"release/1.0.0": "a100000",
+		"release/1.1.0": "b100000",
+		"release/1.2.0": "c400000",
+	}
+	ctx.GitContext.Parents = map[string][]string{
+		"b100000": {"a100000"},
+		"c100000": {"b100000"},
+		"c200000": {"c100000"},
+		"c300000": {"c200000"},
+		"c400000": {"c300000"},
+	}
+	ctx.GitContext.Messages = map[string]string{
+		"b100000": "feat: add status",
+		"c100000": "fix(list): handle empty tags",
+		"c200000": "docs: update README",
+		"c300000": "feat!: rename --ls to --remote-only",
+		"c400000": "Tidy up",
+	}
+	ctx.GitContext.CommitDates = map[string]time.Time{
+		"b100000": time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC),
+		"c400000": time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+	}
+
+	return ctx
+}
+
+func TestRunChangelogCmd_ShowsLatestRelease(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+
+	// Act
+	err := runChangelogCmd([]string{}, "", ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"## [1.2.0] - 2026-10-18",
+		"",
+		"### Added",
+		"",
+		"- **BREAKING:** rename --ls to --remote-only (c300000)",
+		"",
+		"### Fixed",
+		"",
+		"- **list:** handle empty tags (c100000)",
+		"",
+		"### Other",
+		"",
+		"- Tidy up (c400000)",
+	)
+}
+
+func TestRunChangelogCmd_ResolvesVersionPrefixes(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+
+	// Act
+	err := runChangelogCmd([]string{"1.0", "1.1"}, "", ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.OutputContext.AssertOutputLines(
+		"## [1.1.0] - 2026-09-01",
+		"",
+		"### Added",
+		"",
+		"- add status (b100000)",
+	)
+}
+
+func TestRunChangelogCmd_DatesReleaseByTag(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	ctx.CommandContext.Mode = "both"
+	ctx.GitContext.Tags = []string{"v1.2.0"}
+	ctx.GitContext.Commits["v1.2.0"] = "c400000"
+	ctx.GitContext.RefDates = map[string]time.Time{
+		"refs/tags/v1.2.0": time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC),
+	}
+
+	// Act
+	err := runChangelogCmd([]string{}, "", ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.OutputContext.AssertOutputLines(
+		"## [1.2.0] - 2026-10-20",
+		"",
+		"### Added",
+		"",
+		"- **BREAKING:** rename --ls to --remote-only (c300000)",
+		"",
+		"### Fixed",
+		"",
+		"- **list:** handle empty tags (c100000)",
+		"",
+		"### Other",
+		"",
+		"- Tidy up (c400000)",
+	)
+}
+
+func TestRunChangelogCmd_GroupsByPattern(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	ctx.CommandContext.ChangelogPattern = `^(\w+)(?:\([^)]*\))?!?: `
+
+	// Act
+	err := runChangelogCmd([]string{"1.1.0"}, "", ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.OutputContext.AssertOutputLines(
+		"## [1.2.0] - 2026-10-18",
+		"",
+		"### feat",
+		"",
+		"- rename --ls to --remote-only (c300000)",
+		"",
+		"### docs",
+		"",
+		"- update README (c200000)",
+		"",
+		"### fix",
+		"",
+		"- handle empty tags (c100000)",
+		"",
+		"### Other",
+		"",
+		"- Tidy up (c400000)",
+	)
+}
+
+func TestRunChangelogCmd_UnknownVersion(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+
+	// Act
+	err := runChangelogCmd([]string{"3"}, "", ctx)
+
+	// Assert
+	if code := exitCode(err); code != ExitNotFound {
+		t.Errorf("Expected exit code %d, got %d (%v)", ExitNotFound, code, err)
+	}
+}
+
+func TestRunChangelogCmd_WritesChangelogFile(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	err := os.WriteFile(path, []byte("# Changelog\n\n## [Unreleased]\n\n## [1.1.0] - 2026-09-01\n\n- Older\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	err = runChangelogCmd([]string{}, path, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "# Changelog\n\n## [Unreleased]\n\n## [1.2.0] - 2026-10-18\n"
+	if !strings.HasPrefix(string(contents), expected) || !strings.HasSuffix(string(contents), "- Tidy up (c400000)\n\n## [1.1.0] - 2026-09-01\n\n- Older\n") {
+		t.Fatalf("Unexpected changelog:\n%s", contents)
+	}
+}
+
+func TestRunNewCmd_CommitsChangelogToReleaseBranch(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	ctx.CommandContext.ChangelogFile = "docs/CHANGELOG.md"
+	ctx.GitContext.Files = map[string]string{
+		"docs/CHANGELOG.md": "# Changelog\n\n## [1.2.0] - 2026-10-18\n\n- Older\n",
+	}
+	ctx.GitContext.Commits["release/1.3.0"] = "d100000"
+	ctx.GitContext.Parents["d100000"] = []string{"c400000"}
+	ctx.GitContext.Messages["d100000"] = "feat: add changelog"
+	ctx.GitContext.CommitDates["d100000"] = time.Date(2026, 10, 18, 14, 0, 0, 0, time.UTC)
+
+	// Act
+	err := runNewCmd([]string{"1.3.0"}, "", ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("release/1.3.0"),
+		gitrel_test.EffectCheckoutBranch("release/1.3.0"),
+		gitrel_test.EffectPushBranch("origin", "release/1.3.0"),
+		gitrel_test.EffectCheckoutBranch("main"),
+		gitrel_test.EffectCommitFiles("release/1.3.0", "docs: update docs/CHANGELOG.md for 1.3.0", "docs/CHANGELOG.md"),
+		gitrel_test.EffectPushBranch("origin", "release/1.3.0"),
+	)
+
+	expected := "# Changelog\n\n## [1.3.0] - 2026-10-18\n\n### Added\n\n- add changelog (d100000)\n\n## [1.2.0] - 2026-10-18\n\n- Older\n"
+	if actual := ctx.GitContext.CommittedFiles["docs/CHANGELOG.md"]; actual != expected {
+		t.Fatalf("Unexpected changelog:\n%s", actual)
+	}
+}
+
+func TestRunNewCmd_FailsWhenChangelogCannotBeUpdated(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	ctx.CommandContext.ChangelogFile = "CHANGELOG.md"
+	ctx.CommandContext.IssuePattern = "("
+
+	// Act
+	err := runNewCmd([]string{"1.3.0"}, "", ctx)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "error updating CHANGELOG.md") {
+		t.Fatalf("Expected an error updating the changelog, got %v", err)
+	}
+
+	if _, ok := ctx.GitContext.CommittedFiles["CHANGELOG.md"]; ok {
+		t.Fatal("Expected the changelog not to be committed")
+	}
+}
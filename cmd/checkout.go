@@ -3,6 +3,7 @@ package cmd
 import (
 	"gitrel/git"
 	"gitrel/interfaces"
+	"gitrel/semver"
 
 	"github.com/spf13/cobra"
 )
@@ -17,8 +18,7 @@ var checkoutCmd = &cobra.Command{
 			return err
 		}
 
-		runCheckoutCmd(args, ctx)
-		return nil
+		return runCheckoutCmd(args, ctx)
 	},
 }
 
@@ -36,8 +36,7 @@ var checkoutLatestCmd = &cobra.Command{
 			return err
 		}
 
-		runCheckoutCmd([]string{"latest"}, ctx)
-		return nil
+		return runCheckoutCmd([]string{"latest"}, ctx)
 	},
 }
 
@@ -46,11 +45,29 @@ func init() {
 	checkoutCmd.AddCommand(checkoutLatestCmd)
 }
 
-func runCheckoutCmd(args []string, ctx interfaces.GitRelContext) {
+func runCheckoutCmd(args []string, ctx interfaces.GitRelContext) error {
 	err := git.CheckoutVersion(args[0], ctx)
-	if err != nil {
-		ctx.Output().Println(err)
+
+	// The status goes in the result, so structured output holds one document
+	var status *git.Status
+	if err == nil {
+		status, err = git.GetStatus(ctx)
+	} else {
+		status = failedCheckoutStatus(ctx)
+	}
+
+	result := git.NewOperationResult("checkout", semver.Version{}, err)
+	result.Status = status
+	return emitOperationResult(result, err, ctx)
+}
+
+// failedCheckoutStatus returns the status to show with a failed checkout, or
+// nil if there are no releases at all, as there is nothing useful to show
+func failedCheckoutStatus(ctx interfaces.GitRelContext) *git.Status {
+	status, err := git.GetStatus(ctx)
+	if err != nil || len(status.Releases) == 0 {
+		return nil
 	}
 
-	git.ShowStatus(ctx)
+	return status
 }
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"gitrel/git"
 	"gitrel/gitrel_test"
 	"testing"
@@ -201,3 +203,84 @@ func TestCheckoutLatest_ChecksOutLatestRelease(t *testing.T) {
 	)
 }
 
+func TestCheckoutLatest_PrefersReleaseOverPrereleases(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.2.3-rc.1",
+		"release/1.2.3",
+		"release/1.2.3-rc.2",
+	}
+
+	// Act
+	runCheckoutCmd([]string{"latest"}, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(gitrel_test.EffectCheckoutBranch("release/1.2.3"))
+}
+
+func TestRunCheckoutCmd_TagsMode_ChecksOutReleaseTag(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Mode = "tags"
+	ctx.GitContext.Tags = []string{
+		"v1.0.0",
+		"v1.0.1",
+		"v1.1.0",
+	}
+
+	// Act
+	runCheckoutCmd([]string{"1.0"}, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(gitrel_test.EffectCheckoutBranch("v1.0.1"))
+	ctx.OutputContext.AssertOutputLines(
+		"Checking out release tag: v1.0.1",
+		gitrel_test.GetStdOutIgnoreSideEffects(ctx, func(ctx2 *gitrel_test.TestGitRelContext) {
+			git.ShowStatus(ctx2)
+		}),
+	)
+}
+
+func TestRunCheckoutCmd_JSON_WritesOneDocument(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.0.0",
+		"release/2.0.0",
+	}
+
+	formatter, _ := getFormatter(OutputJSON)
+
+	// Act
+	runCheckoutCmd([]string{"2.0.0"}, ctx)
+
+	// Assert
+	var out bytes.Buffer
+	for _, result := range ctx.OutputContext.Results {
+		err := formatter.Format(&out, result)
+		if err != nil {
+			t.Fatalf("error formatting result: %v", err)
+		}
+	}
+
+	var document struct {
+		Operation string
+		Success   bool
+		Status    *git.Status
+	}
+	err := json.Unmarshal(out.Bytes(), &document)
+	if err != nil {
+		t.Fatalf("expected one JSON document, got %s: %v", out.String(), err)
+	}
+
+	if document.Operation != "checkout" || !document.Success {
+		t.Fatalf("expected a successful checkout, got %s", out.String())
+	}
+
+	if document.Status == nil || len(document.Status.Releases) != 2 {
+		t.Fatalf("expected the status with 2 releases, got %s", out.String())
+	}
+}
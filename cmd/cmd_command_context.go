@@ -1,11 +1,14 @@
 package cmd
 
 import (
-	"errors"
 	"gitrel/config"
+	"gitrel/conventional"
 	"gitrel/git"
 	"gitrel/interfaces"
+	"gitrel/pattern"
 	"gitrel/utils"
+	"gitrel/versionfile"
+	"regexp"
 )
 
 var commandContext interfaces.CommandContext
@@ -18,14 +21,20 @@ func getCommandContext(gitCtx interfaces.GitContext) (interfaces.CommandContext,
 	ctx := CmdCommandContext{}
 
 	if FetchFlag && NoFetchFlag {
-		return nil, errors.New("cannot use both --fetch and --no-fetch")
+		return nil, usageError("cannot use both --fetch and --no-fetch")
 	}
 
-	ctx.Fetch = FetchFlag || (config.FetchConfig && !NoFetchFlag)
+	if FetchFlag && RemoteOnlyFlag {
+		return nil, usageError("cannot use both --fetch and --remote-only")
+	}
+
+	ctx.RemoteOnly = RemoteOnlyFlag
+	ctx.AllRemotes = AllRemotesFlag
+	ctx.Fetch = FetchFlag || (config.FetchConfig && !NoFetchFlag && !RemoteOnlyFlag)
 
-	ctx.Remote = utils.CoalesceStr(RemoteFlag, config.RemoteConfig, "")
+	ctx.Remote = RemoteFlag
 	if ctx.Remote == "" {
-		remote, err := git.GetDefaultRemote(gitCtx)
+		remote, err := git.GetDefaultRemote(config.RemoteConfig, gitCtx)
 		if err != nil {
 			return nil, err
 		}
@@ -35,16 +44,101 @@ func getCommandContext(gitCtx interfaces.GitContext) (interfaces.CommandContext,
 
 	ctx.LocalBranchName = utils.CoalesceStr(LocalBranchNameFlag, config.LocalBranchNameConfig, "release/%v")
 	ctx.RemoteBranchName = utils.CoalesceStr(RemoteBranchNameFlag, config.RemoteBranchNameConfig, "release/%v")
+	ctx.TagName = utils.CoalesceStr(TagNameFlag, config.TagNameConfig, "v%v")
+	ctx.TagOnRelease = utils.CoalesceStr(TagOnReleaseFlag, config.TagOnReleaseConfig, "")
+	ctx.TagMessage = utils.CoalesceStr(TagMessageFlag, config.TagMessageConfig, "Release %v")
+
+	ctx.Mode = utils.CoalesceStr(ModeFlag, config.ModeConfig, git.ModeBranches)
+	if ctx.Mode != git.ModeBranches && ctx.Mode != git.ModeTags && ctx.Mode != git.ModeBoth {
+		return nil, usageError("invalid mode '%s'. please use one of: branches, tags, both", ctx.Mode)
+	}
+
+	ctx.Line = utils.CoalesceStr(LineFlag, config.LineConfig, git.LinePatch)
+	if ctx.Line != git.LineMajor && ctx.Line != git.LineMinor && ctx.Line != git.LinePatch {
+		return nil, usageError("invalid line '%s'. please use one of: major, minor, patch", ctx.Line)
+	}
+
+	ctx.Component = utils.CoalesceStr(ComponentFlag, config.ComponentConfig, "")
+
+	bumpTypes, err := conventional.ParseBumpRules(utils.CoalesceStr(BumpTypesFlag, config.BumpTypesConfig, ""))
+	if err != nil {
+		return nil, usageError("%s", err)
+	}
+
+	ctx.BumpTypes = bumpTypes
+
+	ctx.ChangelogFile = utils.CoalesceStr(ChangelogFileFlag, config.ChangelogFileConfig, "")
+	ctx.ChangelogPattern = utils.CoalesceStr(ChangelogPatternFlag, config.ChangelogPatternConfig, "")
+	if _, err := regexp.Compile(ctx.ChangelogPattern); err != nil {
+		return nil, usageError("invalid changelog pattern: %s", err)
+	}
+
+	ctx.IssuePattern = utils.CoalesceStr(IssuePatternFlag, config.IssuePatternConfig, "")
+	if _, err := regexp.Compile(ctx.IssuePattern); err != nil {
+		return nil, usageError("invalid issue pattern: %s", err)
+	}
+
+	ctx.NotesTemplate = utils.CoalesceStr(TemplateFlag, config.NotesTemplateConfig, "")
+
+	versionFiles, err := versionfile.ParseFiles(utils.CoalesceStr(VersionFilesFlag, config.VersionFilesConfig, ""))
+	if err != nil {
+		return nil, usageError("%s", err)
+	}
+
+	// Version files are committed onto the release branch, which tags mode
+	// doesn't have
+	if len(versionFiles) > 0 && ctx.Mode == git.ModeTags {
+		return nil, usageError("version files can't be used with mode=tags, as there is no release branch to commit them to")
+	}
+
+	if ctx.ChangelogFile != "" && ctx.Mode == git.ModeTags {
+		return nil, usageError("changelogFile can't be used with mode=tags, as there is no release branch to commit it to")
+	}
+
+	ctx.VersionFiles = versionFiles
+	ctx.VersionCommitMessage = utils.CoalesceStr(VersionCommitMessageFlag, config.VersionCommitMessageConfig, "Bump version to %v")
+
+	for _, namePattern := range []string{ctx.LocalBranchName, ctx.RemoteBranchName, ctx.TagName} {
+		t, err := pattern.Compile(namePattern)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Has("component") && ctx.Component == "" {
+			return nil, usageError("'%s' uses {component}, but no component is set. please set component in .gitrelrc or use --component", namePattern)
+		}
+	}
+
+	for _, tagTemplate := range []string{ctx.TagOnRelease, ctx.TagMessage} {
+		if _, err := pattern.CompileTag(tagTemplate); err != nil {
+			return nil, usageError("%s", err)
+		}
+	}
 
 	commandContext = &ctx
 	return &ctx, nil
 }
 
 type CmdCommandContext struct {
-	Fetch            bool
-	Remote           string
-	LocalBranchName  string
-	RemoteBranchName string
+	Fetch                bool
+	RemoteOnly           bool
+	AllRemotes           bool
+	Remote               string
+	LocalBranchName      string
+	RemoteBranchName     string
+	Mode                 string
+	Line                 string
+	Component            string
+	TagName              string
+	TagOnRelease         string
+	TagMessage           string
+	BumpTypes            map[string]string
+	ChangelogFile        string
+	ChangelogPattern     string
+	IssuePattern         string
+	NotesTemplate        string
+	VersionFiles         []versionfile.File
+	VersionCommitMessage string
 
 	fetched bool
 }
@@ -53,6 +147,14 @@ func (c *CmdCommandContext) GetOptFetch() bool {
 	return c.Fetch
 }
 
+func (c *CmdCommandContext) GetOptRemoteOnly() bool {
+	return c.RemoteOnly
+}
+
+func (c *CmdCommandContext) GetOptAllRemotes() bool {
+	return c.AllRemotes
+}
+
 func (c *CmdCommandContext) GetOptRemote() string {
 	return c.Remote
 }
@@ -65,6 +167,58 @@ func (c *CmdCommandContext) GetOptRemoteBranchName() string {
 	return c.RemoteBranchName
 }
 
+func (c *CmdCommandContext) GetOptMode() string {
+	return c.Mode
+}
+
+func (c *CmdCommandContext) GetOptLine() string {
+	return c.Line
+}
+
+func (c *CmdCommandContext) GetOptComponent() string {
+	return c.Component
+}
+
+func (c *CmdCommandContext) GetOptTagName() string {
+	return c.TagName
+}
+
+func (c *CmdCommandContext) GetOptTagOnRelease() string {
+	return c.TagOnRelease
+}
+
+func (c *CmdCommandContext) GetOptTagMessage() string {
+	return c.TagMessage
+}
+
+func (c *CmdCommandContext) GetOptBumpTypes() map[string]string {
+	return c.BumpTypes
+}
+
+func (c *CmdCommandContext) GetOptChangelogFile() string {
+	return c.ChangelogFile
+}
+
+func (c *CmdCommandContext) GetOptChangelogPattern() string {
+	return c.ChangelogPattern
+}
+
+func (c *CmdCommandContext) GetOptIssuePattern() string {
+	return c.IssuePattern
+}
+
+func (c *CmdCommandContext) GetOptNotesTemplate() string {
+	return c.NotesTemplate
+}
+
+func (c *CmdCommandContext) GetOptVersionFiles() []versionfile.File {
+	return c.VersionFiles
+}
+
+func (c *CmdCommandContext) GetOptVersionCommitMessage() string {
+	return c.VersionCommitMessage
+}
+
 func (c *CmdCommandContext) SetFetched(fetched bool) {
 	c.fetched = fetched
 }
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"gitrel/config"
 	"gitrel/git"
 	"gitrel/interfaces"
+	"gitrel/utils"
 )
 
 type CmdGitRelContext struct {
@@ -12,21 +14,49 @@ type CmdGitRelContext struct {
 }
 
 func NewCmdGitRelContext() (*CmdGitRelContext, error) {
-	gitCtx := git.NewCmdGitContext()
+	gitCtx, err := newGitContext()
+	if err != nil {
+		return nil, err
+	}
+
+	if DryRunFlag {
+		dryRunPlan = git.NewPlanGitContext(gitCtx)
+		gitCtx = dryRunPlan
+	}
+
 	cmdCtx, err := getCommandContext(gitCtx)
 	if err != nil {
 		return nil, err
 	}
 
+	output, err := NewCmdOutputContext(OutputFlag)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx := &CmdGitRelContext{
 		options: cmdCtx,
 		git:     gitCtx,
-		output:  NewCmdOutputContext(),
+		output:  output,
 	}
 
 	return ctx, nil
 }
 
+// newGitContext returns the git backend chosen by --backend or the backend
+// config, running the git command by default
+func newGitContext() (interfaces.GitContext, error) {
+	backend := utils.CoalesceStr(BackendFlag, config.BackendConfig, git.BackendExec)
+	switch backend {
+	case git.BackendExec:
+		return git.NewCmdGitContext(), nil
+	case git.BackendNative:
+		return git.NewNativeGitContext()
+	}
+
+	return nil, usageError("invalid backend '%s'. please use one of: exec, native", backend)
+}
+
 func (c *CmdGitRelContext) Command() interfaces.CommandContext {
 	return c.options
 }
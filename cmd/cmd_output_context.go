@@ -1,24 +1,110 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"gitrel/interfaces"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
 )
 
-type CmdOutputContext struct{}
+// Formatter writes a result as a document in one output format
+type Formatter interface {
+	Format(w io.Writer, result interfaces.Result) error
+}
+
+// CmdOutputContext writes results to stdout in the selected format. Progress
+// messages go to stdout alongside text results, and to stderr otherwise so
+// stdout holds only the documents.
+type CmdOutputContext struct {
+	out       io.Writer
+	messages  io.Writer
+	formatter Formatter
+}
+
+func NewCmdOutputContext(format string) (interfaces.OutputContext, error) {
+	formatter, err := getFormatter(format)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := io.Writer(os.Stdout)
+	if format != OutputText {
+		messages = os.Stderr
+	}
+
+	return &CmdOutputContext{
+		out:       os.Stdout,
+		messages:  messages,
+		formatter: formatter,
+	}, nil
+}
+
+func getFormatter(format string) (Formatter, error) {
+	switch format {
+	case OutputText:
+		return textFormatter{}, nil
+	case OutputJSON:
+		return jsonFormatter{}, nil
+	case OutputYAML:
+		return yamlFormatter{}, nil
+	}
 
-func NewCmdOutputContext() interfaces.OutputContext {
-	return &CmdOutputContext{}
+	return nil, usageError("invalid output format: %s. use one of: text, json, yaml", format)
 }
 
 func (c *CmdOutputContext) Print(args ...interface{}) {
-	fmt.Print(args...)
+	fmt.Fprint(c.messages, args...)
 }
 
 func (c *CmdOutputContext) Println(args ...interface{}) {
-	fmt.Println(args...)
+	fmt.Fprintln(c.messages, args...)
 }
 
 func (c *CmdOutputContext) Printf(format string, args ...interface{}) {
-	fmt.Printf(format, args...)
+	fmt.Fprintf(c.messages, format, args...)
+}
+
+func (c *CmdOutputContext) Emit(result interfaces.Result) {
+	err := c.formatter.Format(c.out, result)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error writing output:", err)
+	}
+}
+
+type textFormatter struct{}
+
+func (f textFormatter) Format(w io.Writer, result interfaces.Result) error {
+	result.RenderText(&CmdOutputContext{out: w, messages: w, formatter: f})
+	return nil
+}
+
+type jsonFormatter struct{}
+
+func (f jsonFormatter) Format(w io.Writer, result interfaces.Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// yamlFormatter starts every document with a separator, so commands that
+// emit more than one result still produce a valid stream
+type yamlFormatter struct{}
+
+func (f yamlFormatter) Format(w io.Writer, result interfaces.Result) error {
+	data, err := yaml.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "---\n%s", data)
+	return err
 }
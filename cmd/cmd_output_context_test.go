@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"gitrel/git"
+	"gitrel/gitrel_test"
+	"strings"
+	"testing"
+)
+
+func formatListResult(t *testing.T, format string) string {
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.0.0",
+		"remotes/origin/release/1.0.0",
+		"release/1.1.0",
+	}
+
+	releases, err := git.ListReleases(ctx)
+	if err != nil {
+		t.Fatalf("error listing releases: %v", err)
+	}
+
+	formatter, err := getFormatter(format)
+	if err != nil {
+		t.Fatalf("error getting formatter: %v", err)
+	}
+
+	var out bytes.Buffer
+	err = formatter.Format(&out, git.NewReleaseList(releases, ctx))
+	if err != nil {
+		t.Fatalf("error formatting result: %v", err)
+	}
+
+	return out.String()
+}
+
+func TestFormatter_Text_RendersReleaseList(t *testing.T) {
+	// Act
+	output := formatListResult(t, OutputText)
+
+	// Assert
+	expected := strings.Join([]string{
+		"Current release branches:",
+		"1.0.0",
+		"1.1.0 (local only)",
+		"",
+	}, "\n")
+	if output != expected {
+		t.Fatalf("expected %q, got %q", expected, output)
+	}
+}
+
+func TestFormatter_JSON_WritesReleaseListDocument(t *testing.T) {
+	// Act
+	output := formatListResult(t, OutputJSON)
+
+	// Assert
+	expected := `{
+  "remote": "origin",
+  "releases": [
+    {
+      "version": "1.0.0",
+      "local": [
+        "release/1.0.0"
+      ],
+      "remote": [
+        "remotes/origin/release/1.0.0"
+      ],
+      "tags": [],
+      "localOnly": false
+    },
+    {
+      "version": "1.1.0",
+      "local": [
+        "release/1.1.0"
+      ],
+      "remote": [],
+      "tags": [],
+      "localOnly": true
+    }
+  ]
+}
+`
+	if output != expected {
+		t.Fatalf("expected %s, got %s", expected, output)
+	}
+}
+
+func TestFormatter_YAML_WritesReleaseListDocument(t *testing.T) {
+	// Act
+	output := formatListResult(t, OutputYAML)
+
+	// Assert
+	expected := `---
+remote: origin
+releases:
+    - version: 1.0.0
+      local:
+        - release/1.0.0
+      remote:
+        - remotes/origin/release/1.0.0
+      tags: []
+      localOnly: false
+    - version: 1.1.0
+      local:
+        - release/1.1.0
+      remote: []
+      tags: []
+      localOnly: true
+`
+	if output != expected {
+		t.Fatalf("expected %s, got %s", expected, output)
+	}
+}
+
+func TestFormatter_JSON_WritesOperationResult(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	runNewCmd([]string{"2.0.0"}, "", ctx)
+
+	formatter, _ := getFormatter(OutputJSON)
+
+	// Act
+	var out bytes.Buffer
+	err := formatter.Format(&out, ctx.OutputContext.Results[0])
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error formatting result: %v", err)
+	}
+
+	expected := `{
+  "operation": "new",
+  "version": "2.0.0",
+  "success": false,
+  "error": "branch release/2.0.0 already exists"
+}
+`
+	if out.String() != expected {
+		t.Fatalf("expected %s, got %s", expected, out.String())
+	}
+}
+
+func TestGetFormatter_RejectsUnknownFormat(t *testing.T) {
+	// Act
+	_, err := getFormatter("xml")
+
+	// Assert
+	if err == nil || err.Error() != "invalid output format: xml. use one of: text, json, yaml" {
+		t.Fatalf("expected invalid output format error, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+	"gitrel/semver"
+
+	"github.com/spf13/cobra"
+)
+
+var BuildVersionFlag bool
+
+var describeCmd = &cobra.Command{
+	Use:   "describe [<commit>]",
+	Short: "Show the releases containing a commit, the nearest release before it and its build version",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runDescribeCmd(args, BuildVersionFlag, ctx)
+	},
+}
+
+func init() {
+	describeCmd.Flags().BoolVar(&BuildVersionFlag, "build-version", false, "Print only the build version, e.g. 1.2.3-dev.5+gabc1234")
+}
+
+func runDescribeCmd(args []string, buildVersionOnly bool, ctx interfaces.GitRelContext) error {
+	commit := "HEAD"
+	if len(args) > 0 {
+		commit = args[0]
+	}
+
+	description, err := git.DescribeCommit(commit, ctx)
+	if err != nil {
+		return emitResult("describe", semver.Version{}, err, ctx)
+	}
+
+	description.VersionOnly = buildVersionOnly
+	ctx.Output().Emit(description)
+	return nil
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/gitrel_test"
+	"testing"
+)
+
+// describeTestContext has releases 1.0.0 and 1.1.0 on main, 2.0.0 branched
+// from 1.1.0, and a feature branch three commits after 1.1.0
+func describeTestContext(t *testing.T) *gitrel_test.TestGitRelContext {
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"feature",
+		"release/1.0.0",
+		"release/1.1.0",
+		"release/2.0.0",
+	}
+	ctx.GitContext.CurrentBranch = "feature"
+	ctx.GitContext.Commits = map[string]string{
+		"HEAD":          "f300000",
+		"feature":       "f300000",
+		"main":          "b100000",
+		"release/1.0.0": "a100000",
+		"release/1.1.0": "b100000",
+		"release/2.0.0": "c100000",
+	}
+	ctx.GitContext.Parents = map[string][]string{
+		"b100000": {"a100000"},
+		"c100000": {"b100000"},
+		"f100000": {"b100000"},
+		"f200000": {"f100000"},
+		"f300000": {"f200000"},
+	}
+
+	return ctx
+}
+
+func TestRunDescribeCmd_FeatureBranch(t *testing.T) {
+	// Arrange
+	ctx := describeTestContext(t)
+
+	// Act
+	err := runDescribeCmd([]string{}, false, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"Commit: f300000",
+		"Contained in: (no release)",
+		"Nearest release: 1.1.0 (3 commits ago)",
+		"Build version: 1.1.1-dev.3+gf300000",
+	)
+}
+
+func TestRunDescribeCmd_ReleaseCommit(t *testing.T) {
+	// Arrange
+	ctx := describeTestContext(t)
+
+	// Act
+	err := runDescribeCmd([]string{"b100000"}, false, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.OutputContext.AssertOutputLines(
+		"Commit: b100000",
+		"Contained in: 1.1.0, 2.0.0",
+		"Nearest release: 1.1.0 (this commit)",
+		"Build version: 1.1.0",
+	)
+}
+
+func TestRunDescribeCmd_BuildVersionOnly(t *testing.T) {
+	// Arrange
+	ctx := describeTestContext(t)
+
+	// Act
+	err := runDescribeCmd([]string{"feature"}, true, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.OutputContext.AssertOutputLines(
+		"1.1.1-dev.3+gf300000",
+	)
+}
+
+func TestRunDescribeCmd_AfterPrerelease(t *testing.T) {
+	// Arrange
+	ctx := describeTestContext(t)
+	ctx.GitContext.Branches = append(ctx.GitContext.Branches, "release/2.0.0-rc.1")
+	ctx.GitContext.Commits["release/2.0.0-rc.1"] = "f100000"
+
+	// Act
+	err := runDescribeCmd([]string{}, true, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.OutputContext.AssertOutputLines(
+		"2.0.0-rc.1.dev.2+gf300000",
+	)
+}
+
+func TestRunDescribeCmd_NoReleaseBefore(t *testing.T) {
+	// Arrange
+	ctx := describeTestContext(t)
+	ctx.GitContext.Branches = []string{"main", "feature"}
+
+	// Act
+	err := runDescribeCmd([]string{}, false, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.OutputContext.AssertOutputLines(
+		"Commit: f300000",
+		"Contained in: (no release)",
+		"Nearest release: (none)",
+		"Build version: 0.0.0-dev.5+gf300000",
+	)
+}
+
+func TestRunDescribeCmd_UnknownCommit(t *testing.T) {
+	// Arrange
+	ctx := describeTestContext(t)
+
+	// Act
+	err := runDescribeCmd([]string{"missing"}, false, ctx)
+
+	// Assert
+	assertExitCode(t, err, git.ErrNotFound, ExitNotFound)
+}
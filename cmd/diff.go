@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+	"gitrel/semver"
+
+	"github.com/spf13/cobra"
+)
+
+var StatFlag bool
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <from> <to>",
+	Short: "Show the changes from one release to another",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runDiffCmd(args, StatFlag, ctx)
+	},
+}
+
+func init() {
+	diffCmd.Flags().BoolVar(&StatFlag, "stat", false, "Show the lines added and deleted in each file instead of the patch")
+}
+
+func runDiffCmd(args []string, stat bool, ctx interfaces.GitRelContext) error {
+	diff, err := git.GetDiff(args[0], args[1], stat, ctx)
+	if err != nil {
+		return emitResult("diff", semver.Version{}, err, ctx)
+	}
+
+	ctx.Output().Emit(diff)
+	return nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"gitrel/interfaces"
+	"testing"
+)
+
+func TestRunDiffCmd_ShowsPatchBetweenReleases(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	ctx.GitContext.Diffs = map[string]string{
+		"release/1.1.0..release/1.2.0": "diff --git a/VERSION b/VERSION\n-1.1.0\n+1.2.0\n",
+	}
+
+	// Act
+	err := runDiffCmd([]string{"1.1", "latest"}, false, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"diff --git a/VERSION b/VERSION",
+		"-1.1.0",
+		"+1.2.0",
+	)
+}
+
+func TestRunDiffCmd_Stat_ShowsLinesChangedPerFile(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	ctx.GitContext.CurrentBranch = "release/1.1.0"
+	ctx.GitContext.DiffStats = map[string][]interfaces.FileStat{
+		"release/1.0.0..release/1.1.0": {
+			{Path: "README", OldPath: "README.md", Additions: 1},
+			{Path: "VERSION", Additions: 1, Deletions: 1},
+			{Path: "logo.png", Binary: true},
+			{Path: "git/status.go", Additions: 80, Deletions: 20},
+		},
+	}
+
+	// Act
+	runDiffCmd([]string{"1.0", "current"}, true, ctx)
+
+	// Assert
+	ctx.OutputContext.AssertOutput(
+		" README.md => README |   1 +\n" +
+			" VERSION             |   2 +-\n" +
+			" logo.png            | Bin\n" +
+			" git/status.go       | 100 ++++++++++++++++++++++++++++++++--------\n" +
+			" 4 files changed, 82 insertions(+), 21 deletions(-)\n",
+	)
+}
+
+func TestRunDiffCmd_ReportsNoChanges(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+
+	// Act
+	runDiffCmd([]string{"1.2", "1.2.0"}, true, ctx)
+
+	// Assert
+	ctx.OutputContext.AssertOutputLines(
+		"No changes from 1.2.0 to 1.2.0.",
+	)
+}
+
+func TestRunDiffCmd_FailsWhenNotOnReleaseBranch(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+
+	// Act
+	err := runDiffCmd([]string{"current", "latest"}, false, ctx)
+
+	// Assert
+	if code := exitCode(err); code != 3 {
+		t.Fatalf("expected exit code 3, got %d", code)
+	}
+
+	ctx.OutputContext.AssertOutputLines(
+		"not on a release branch",
+	)
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"gitrel/git"
+)
+
+// dryRunPlan records the git commands of the current command when --dry-run
+// is set, to be shown once the command finishes
+var dryRunPlan *git.PlanGitContext
+
+// dryRunPlanShown is set once the plan has been reported as part of a result
+var dryRunPlanShown bool
+
+// attachDryRunPlan adds the plan of a dry run to the result of the operation
+func attachDryRunPlan(result *git.OperationResult) {
+	if dryRunPlan == nil {
+		return
+	}
+
+	result.DryRun = git.NewDryRunPlan(dryRunPlan)
+	dryRunPlanShown = true
+}
+
+// reportDryRunPlan shows the plan of a dry run whose command didn't report it
+// with its result, e.g. because it failed before getting that far. It is
+// written as progress messages, so structured output holds one document.
+func reportDryRunPlan() {
+	if dryRunPlan == nil || dryRunPlanShown {
+		return
+	}
+
+	output, err := NewCmdOutputContext(OutputFlag)
+	if err != nil {
+		return
+	}
+
+	git.NewDryRunPlan(dryRunPlan).RenderText(output)
+	dryRunPlanShown = true
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"gitrel/git"
+	"gitrel/gitrel_test"
+	"testing"
+)
+
+// dryRunTestContext sets up a dry run over the test repository, which has
+// already planned a fetch
+func dryRunTestContext(t *testing.T) *gitrel_test.TestGitRelContext {
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	dryRunPlan = git.NewPlanGitContext(ctx.GitContext)
+	dryRunPlan.FetchRemote("origin")
+	t.Cleanup(func() {
+		dryRunPlan = nil
+		dryRunPlanShown = false
+	})
+
+	return ctx
+}
+
+func TestEmitResult_DryRun_IncludesPlanWhenOperationFails(t *testing.T) {
+	// Arrange
+	ctx := dryRunTestContext(t)
+
+	// Act
+	runNewCmd([]string{"2.0.0"}, "", ctx)
+
+	// Assert
+	ctx.OutputContext.AssertOutputLines(
+		"branch release/2.0.0 already exists",
+		"Dry run: the following git commands would be run",
+		"  git fetch origin",
+	)
+
+	if !dryRunPlanShown {
+		t.Fatalf("expected the plan to be marked as shown")
+	}
+}
+
+func TestEmitResult_DryRun_WritesPlanInsideJSONResult(t *testing.T) {
+	// Arrange
+	ctx := dryRunTestContext(t)
+	runNewCmd([]string{"2.0.0"}, "", ctx)
+
+	formatter, _ := getFormatter(OutputJSON)
+
+	// Act
+	var out bytes.Buffer
+	err := formatter.Format(&out, ctx.OutputContext.Results[0])
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error formatting result: %v", err)
+	}
+
+	expected := `{
+  "operation": "new",
+  "version": "2.0.0",
+  "success": false,
+  "error": "branch release/2.0.0 already exists",
+  "dryRun": {
+    "commands": [
+      "git fetch origin"
+    ]
+  }
+}
+`
+	if out.String() != expected {
+		t.Fatalf("expected %s, got %s", expected, out.String())
+	}
+}
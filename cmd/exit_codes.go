@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"gitrel/git"
+	"gitrel/interfaces"
+	"gitrel/semver"
+	"gitrel/utils"
+)
+
+// Exit codes, documented in the README. Scripts rely on these, so existing
+// codes must not change.
+const (
+	ExitOK              = 0
+	ExitError           = 1
+	ExitUsage           = 2
+	ExitNotFound        = 3
+	ExitAlreadyExists   = 4
+	ExitDirtyWorktree   = 5
+	ExitMergeConflict   = 6
+	ExitPushRejected    = 7
+	ExitAmbiguousRemote = 8
+)
+
+// errUsage marks invalid flags and arguments
+var errUsage = errors.New("usage error")
+
+func usageError(format string, args ...any) error {
+	return utils.WithKind(errUsage, fmt.Errorf(format, args...))
+}
+
+// exitCode returns the exit code for an error returned by a command
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, errUsage), errors.Is(err, semver.ErrInvalidVersion):
+		return ExitUsage
+	case errors.Is(err, git.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, git.ErrAlreadyExists):
+		return ExitAlreadyExists
+	case errors.Is(err, git.ErrDirtyWorktree):
+		return ExitDirtyWorktree
+	case errors.Is(err, git.ErrMergeConflict):
+		return ExitMergeConflict
+	case errors.Is(err, git.ErrPushRejected):
+		return ExitPushRejected
+	case errors.Is(err, git.ErrAmbiguousRemote):
+		return ExitAmbiguousRemote
+	}
+
+	return ExitError
+}
+
+// reportedError is an error that has already been shown to the user, so
+// Execute only needs to exit with its code
+type reportedError struct {
+	err error
+}
+
+func (e *reportedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *reportedError) Unwrap() error {
+	return e.err
+}
+
+// emitResult reports the outcome of an operation, with the plan of a dry run,
+// returning its error so the command exits with the matching code
+func emitResult(operation string, version semver.Version, err error, ctx interfaces.GitRelContext) error {
+	return emitOperationResult(git.NewOperationResult(operation, version, err), err, ctx)
+}
+
+// emitOperationResult is like emitResult, for a result the command has filled
+// in further
+func emitOperationResult(result *git.OperationResult, err error, ctx interfaces.GitRelContext) error {
+	attachDryRunPlan(result)
+	ctx.Output().Emit(result)
+	if err == nil {
+		return nil
+	}
+
+	return &reportedError{err}
+}
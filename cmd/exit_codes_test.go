@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"errors"
+	"gitrel/git"
+	"gitrel/gitrel_test"
+	"testing"
+)
+
+func TestExitCode_NoError(t *testing.T) {
+	// Act
+	code := exitCode(nil)
+
+	// Assert
+	if code != ExitOK {
+		t.Errorf("Expected exit code %d, got %d", ExitOK, code)
+	}
+}
+
+func TestExitCode_PlainError(t *testing.T) {
+	// Act
+	code := exitCode(errors.New("something went wrong"))
+
+	// Assert
+	if code != ExitError {
+		t.Errorf("Expected exit code %d, got %d", ExitError, code)
+	}
+}
+
+func TestExitCode_NewExistingVersion(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+
+	// Act
+	err := runNewCmd([]string{"2.0.0"}, "", ctx)
+
+	// Assert
+	assertExitCode(t, err, git.ErrAlreadyExists, ExitAlreadyExists)
+}
+
+func TestExitCode_CheckoutMissingVersion(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+
+	// Act
+	err := runCheckoutCmd([]string{"9.9.9"}, ctx)
+
+	// Assert
+	assertExitCode(t, err, git.ErrNotFound, ExitNotFound)
+}
+
+func TestExitCode_UpdateWithUncommittedChanges(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.HasUncommittedChangesFl = true
+
+	// Act
+	err := runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+
+	// Assert
+	assertExitCode(t, err, git.ErrDirtyWorktree, ExitDirtyWorktree)
+}
+
+func TestExitCode_UpdateWithMergeConflict(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.ConflictingBranches = []string{"release/2.0.0"}
+
+	// Act
+	err := runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+
+	// Assert
+	assertExitCode(t, err, git.ErrMergeConflict, ExitMergeConflict)
+}
+
+func TestExitCode_NewWithRejectedPush(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.RejectedPushes = []string{"release/3.0.0"}
+
+	// Act
+	err := runNewCmd([]string{"3.0.0"}, "", ctx)
+
+	// Assert
+	assertExitCode(t, err, git.ErrPushRejected, ExitPushRejected)
+}
+
+func TestExitCode_AmbiguousRemote(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Remotes = []string{"fork", "upstream"}
+
+	// Act
+	_, err := git.GetDefaultRemote("", ctx.GitContext)
+
+	// Assert
+	assertExitCode(t, err, git.ErrAmbiguousRemote, ExitAmbiguousRemote)
+}
+
+func TestExitCode_InvalidVersion(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+
+	// Act
+	err := runNewCmd([]string{"not-a-version"}, "", ctx)
+
+	// Assert
+	if code := exitCode(err); code != ExitUsage {
+		t.Errorf("Expected exit code %d, got %d (%v)", ExitUsage, code, err)
+	}
+}
+
+func assertExitCode(t *testing.T, err error, kind error, expected int) {
+	t.Helper()
+
+	if !errors.Is(err, kind) {
+		t.Errorf("Expected error to be %v, got %v", kind, err)
+	}
+
+	if code := exitCode(err); code != expected {
+		t.Errorf("Expected exit code %d, got %d", expected, code)
+	}
+}
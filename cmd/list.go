@@ -3,10 +3,13 @@ package cmd
 import (
 	"gitrel/git"
 	"gitrel/interfaces"
+	"gitrel/semver"
 
 	"github.com/spf13/cobra"
 )
 
+var AllRemotesFlag bool
+
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List current release branches",
@@ -16,29 +19,20 @@ var listCmd = &cobra.Command{
 			return err
 		}
 
-		runListCmd(ctx)
-		return nil
+		return runListCmd(ctx)
 	},
 }
 
-func runListCmd(ctx interfaces.GitRelContext) {
-	releaseBranches, err := git.ListReleases(ctx)
-	if err != nil {
-		ctx.Output().Println(err)
-		return
-	}
+func init() {
+	listCmd.Flags().BoolVar(&AllRemotesFlag, "all-remotes", false, "List releases from every remote, not just the selected one")
+}
 
-	if len(releaseBranches) == 0 {
-		ctx.Output().Println("No release branches found.")
-		return
+func runListCmd(ctx interfaces.GitRelContext) error {
+	releases, err := git.ListReleases(ctx)
+	if err != nil {
+		return emitResult("list", semver.Version{}, err, ctx)
 	}
 
-	ctx.Output().Println("Current release branches:")
-	for _, branch := range releaseBranches {
-		if branch.IsLocalOnly() {
-			ctx.Output().Println(branch.Version + " (local only)")
-		} else {
-			ctx.Output().Println(branch.Version)
-		}
-	}
+	ctx.Output().Emit(git.NewReleaseList(releases, ctx))
+	return nil
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"gitrel/gitrel_test"
 	"testing"
+	"time"
 )
 
 func TestRunListCmd_PrintsAllReleaseBranches(t *testing.T) {
@@ -90,3 +91,238 @@ func TestRunListCmd_HandlesDifferentBranchNamingConvention_BetweenRemoteAndLocal
 		"2.0.0",
 	)
 }
+
+func TestRunListCmd_TagsMode_PrintsAllReleaseTags(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Mode = "tags"
+	ctx.GitContext.Tags = []string{
+		"v1.0.0",
+		"v1.1.0",
+		"other-tag",
+	}
+
+	// Act
+	runListCmd(ctx)
+
+	// Assert
+	ctx.OutputContext.AssertOutputLines(
+		"Current release branches:",
+		"1.0.0",
+		"1.1.0",
+	)
+}
+
+func TestRunListCmd_TagsMode_IgnoresTagOnReleaseTags(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Mode = "tags"
+	ctx.CommandContext.TagOnRelease = "v%v+%n"
+	ctx.GitContext.Tags = []string{
+		"v1.0.0",
+		"v1.2.0",
+		"v1.2.0+1",
+		"v1.2.0+2",
+	}
+
+	// Act
+	runListCmd(ctx)
+
+	// Assert
+	ctx.OutputContext.AssertOutputLines(
+		"Current release branches:",
+		"1.0.0",
+		"1.2.0",
+	)
+}
+
+func TestRunListCmd_HandlesPatternWithTextAfterVersion(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.LocalBranchName = "rel-%v-stable"
+	ctx.CommandContext.RemoteBranchName = "rel-%v-stable"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"rel-1.0.0-stable",
+		"remotes/origin/rel-1.0.0-stable",
+		"remotes/origin/rel-1.1.0-rc.1-stable",
+		"rel-1.2.0-unstable",
+	}
+
+	// Act
+	runListCmd(ctx)
+
+	// Assert
+	ctx.OutputContext.AssertOutputLines(
+		"Current release branches:",
+		"1.0.0",
+		"1.1.0-rc.1",
+	)
+}
+
+func TestRunListCmd_HandlesNamedPlaceholdersAndComponent(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Component = "api"
+	ctx.CommandContext.LocalBranchName = "{component}/release/{major}.{minor}.{patch}"
+	ctx.CommandContext.RemoteBranchName = "{component}/release/{major}.{minor}.{patch}"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"api/release/1.0.0",
+		"remotes/origin/api/release/1.1.0",
+		"web/release/2.0.0",
+		"remotes/origin/web/release/2.1.0",
+	}
+
+	// Act
+	runListCmd(ctx)
+
+	// Assert
+	ctx.OutputContext.AssertOutputLines(
+		"Current release branches:",
+		"1.0.0 (local only)",
+		"1.1.0",
+	)
+}
+
+func TestRunListCmd_ReadsRemoteNamesWithBraces(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Remote = "fork{1}"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.0.0",
+		"remotes/fork{1}/release/1.0.0",
+	}
+
+	// Act
+	runListCmd(ctx)
+
+	// Assert
+	ctx.OutputContext.AssertOutputLines(
+		"Current release branches:",
+		"1.0.0",
+	)
+}
+
+func TestRunListCmd_PrintsTipCommitAndDate(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.0.0",
+		"remotes/origin/release/1.0.0",
+		"release/2.0.0",
+		"remotes/origin/release/10.0.0",
+	}
+	ctx.GitContext.Commits = map[string]string{
+		"release/1.0.0":                 "1111111aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		"remotes/origin/release/1.0.0":  "2222222bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+		"release/2.0.0":                 "3333333ccccccccccccccccccccccccccccccccc",
+		"remotes/origin/release/10.0.0": "4444444ddddddddddddddddddddddddddddddddd",
+	}
+	ctx.GitContext.CommitDates = map[string]time.Time{
+		"release/1.0.0":                 time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+		"remotes/origin/release/1.0.0":  time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		"release/2.0.0":                 time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC),
+		"remotes/origin/release/10.0.0": time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC),
+	}
+
+	// Act
+	runListCmd(ctx)
+
+	// Assert
+	ctx.OutputContext.AssertOutputLines(
+		"Current release branches:",
+		"1.0.0               1111111  2024-01-02",
+		"2.0.0 (local only)  3333333  2024-02-03",
+		"10.0.0              4444444  2024-03-04",
+	)
+}
+
+func TestRunListCmd_RemoteOnly_ListsReleasesOnRemoteWithoutFetching(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Fetch = true
+	ctx.CommandContext.RemoteOnly = true
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.0.0",
+		"release/9.0.0",
+		"remotes/origin/main",
+		"remotes/origin/release/1.0.0",
+	}
+	ctx.GitContext.RemoteRefs = []string{
+		"refs/heads/main",
+		"refs/heads/release/1.0.0",
+		"refs/heads/release/2.0.0",
+		"refs/tags/v2.0.0",
+	}
+
+	// Act
+	runListCmd(ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"Current release branches:",
+		"1.0.0",
+		"2.0.0",
+	)
+}
+
+func TestRunListCmd_RemoteOnly_TagsMode(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.RemoteOnly = true
+	ctx.CommandContext.Mode = "tags"
+	ctx.GitContext.Tags = []string{"v1.0.0"}
+	ctx.GitContext.RemoteRefs = []string{
+		"refs/heads/main",
+		"refs/tags/v1.1.0",
+		"refs/tags/v1.2.0",
+	}
+
+	// Act
+	runListCmd(ctx)
+
+	// Assert
+	ctx.OutputContext.AssertOutputLines(
+		"Current release branches:",
+		"1.1.0",
+		"1.2.0",
+	)
+}
+
+func TestRunListCmd_AllRemotes_ShowsRemotesOfEachRelease(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.AllRemotes = true
+	ctx.CommandContext.Fetch = true
+	ctx.GitContext.Remotes = []string{"origin", "upstream"}
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.0.0",
+		"remotes/origin/release/1.0.0",
+		"remotes/upstream/release/1.0.0",
+		"remotes/upstream/release/2.0.0",
+		"release/3.0.0",
+	}
+
+	// Act
+	runListCmd(ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectFetchRemote("origin"),
+		gitrel_test.EffectFetchRemote("upstream"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Fetching from remote 'origin'...",
+		"Fetching from remote 'upstream'...",
+		"Current release branches:",
+		"1.0.0 (origin, upstream)",
+		"2.0.0 (upstream)",
+		"3.0.0 (local only)",
+	)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+	"gitrel/semver"
+
+	"github.com/spf13/cobra"
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log <from> <to>",
+	Short: "List the commits in one release that aren't in another",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runLogCmd(args, ctx)
+	},
+}
+
+func runLogCmd(args []string, ctx interfaces.GitRelContext) error {
+	log, err := git.GetLog(args[0], args[1], ctx)
+	if err != nil {
+		return emitResult("log", semver.Version{}, err, ctx)
+	}
+
+	ctx.Output().Emit(log)
+	return nil
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"gitrel/git"
+	"gitrel/gitrel_test"
+	"testing"
+	"time"
+)
+
+func TestRunLogCmd_ListsCommitsBetweenReleases(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	ctx.GitContext.Authors = map[string]string{"c100000": "Ada", "c200000": "Ada", "c300000": "Grace", "c400000": "Ada"}
+	ctx.GitContext.CommitDates["c100000"] = time.Date(2026, 10, 2, 12, 0, 0, 0, time.UTC)
+	ctx.GitContext.CommitDates["c200000"] = time.Date(2026, 10, 9, 12, 0, 0, 0, time.UTC)
+	ctx.GitContext.CommitDates["c300000"] = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	// Act
+	err := runLogCmd([]string{"1.1", "latest"}, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"Commits in 1.2.0 since 1.1.0:",
+		"c400000 2026-10-18 Tidy up (Ada)",
+		"c300000 2026-10-16 feat!: rename --ls to --remote-only (Grace)",
+		"c200000 2026-10-09 docs: update README (Ada)",
+		"c100000 2026-10-02 fix(list): handle empty tags (Ada)",
+	)
+}
+
+func TestRunLogCmd_ResolvesCurrentRelease(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	ctx.GitContext.CurrentBranch = "release/1.1.0"
+	ctx.GitContext.Authors = map[string]string{"b100000": "Grace"}
+
+	// Act
+	runLogCmd([]string{"1.0", "current"}, ctx)
+
+	// Assert
+	ctx.OutputContext.AssertOutputLines(
+		"Commits in 1.1.0 since 1.0.0:",
+		"b100000 2026-09-01 feat: add status (Grace)",
+	)
+}
+
+func TestRunLogCmd_MinorLines_ResolvesEarlierPatchTags(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Line = "minor"
+	ctx.GitContext.Branches = []string{"main", "release/0.1"}
+	ctx.GitContext.Tags = []string{"v0.1.0", "v0.1.1", "v0.1.2"}
+	ctx.GitContext.Commits = map[string]string{
+		"release/0.1": "a300000",
+		"v0.1.0":      "a100000",
+		"v0.1.1":      "a200000",
+		"v0.1.2":      "a300000",
+	}
+	ctx.GitContext.Parents = map[string][]string{
+		"a200000": {"a100000"},
+		"a300000": {"a200000"},
+	}
+	ctx.GitContext.Messages = map[string]string{
+		"a200000": "fix: second",
+		"a300000": "fix: third",
+	}
+	ctx.GitContext.Authors = map[string]string{"a200000": "Ada", "a300000": "Ada"}
+	ctx.GitContext.CommitDates = map[string]time.Time{
+		"a200000": time.Date(2026, 10, 2, 12, 0, 0, 0, time.UTC),
+	}
+
+	// Act
+	err := runLogCmd([]string{"0.1.0", "0.1.1"}, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.OutputContext.AssertOutputLines(
+		"Commits in 0.1.1 since 0.1.0:",
+		"a200000 2026-10-02 fix: second (Ada)",
+	)
+}
+
+func TestRunLogCmd_JSONListsCommits(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	ctx.GitContext.Authors = map[string]string{"b100000": "Ada"}
+	runLogCmd([]string{"1.0.0", "1.1.0"}, ctx)
+
+	formatter, _ := getFormatter(OutputJSON)
+
+	// Act
+	var out bytes.Buffer
+	err := formatter.Format(&out, ctx.OutputContext.Results[0])
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error formatting result: %v", err)
+	}
+
+	expected := `{
+  "from": "1.0.0",
+  "to": "1.1.0",
+  "commits": [
+    {
+      "sha": "b100000",
+      "author": "Ada",
+      "date": "2026-09-01T12:00:00Z",
+      "subject": "feat: add status"
+    }
+  ]
+}
+`
+	if out.String() != expected {
+		t.Fatalf("expected %s, got %s", expected, out.String())
+	}
+}
+
+func TestRunLogCmd_FailsForUnknownRelease(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+
+	// Act
+	err := runLogCmd([]string{"1.0", "3"}, ctx)
+
+	// Assert
+	assertExitCode(t, err, git.ErrNotFound, 3)
+	ctx.OutputContext.AssertOutputLines(
+		"no release branches found matching prefix: 3",
+	)
+}
@@ -8,12 +8,20 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	PreidFlag                string
+	FromFlag                 string
+	VersionFilesFlag         string
+	VersionCommitMessageFlag string
+)
+
 var newCmd = &cobra.Command{
 	Use:   "new",
 	Short: "Create a new release branch",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
 			cmd.Help()
+			return &reportedError{usageError("please specify a version, or one of the subcommands above")}
 		}
 
 		ctx, err := NewCmdGitRelContext()
@@ -21,23 +29,35 @@ var newCmd = &cobra.Command{
 			return err
 		}
 
-		runNewCmd(args, ctx)
-		return nil
+		return runNewCmd(args, FromFlag, ctx)
 	},
 }
 
 func init() {
+	newCmd.PersistentFlags().StringVar(&FromFlag, "from", "", "Create the release branch at the given commit-ish instead of HEAD, without switching branches")
+	newCmd.PersistentFlags().StringVar(&VersionFilesFlag, "version-files", "", "Set the version in these files on the new release branch, as path:format[:expression] separated by semicolons (overrides config)")
+	newCmd.PersistentFlags().StringVar(&VersionCommitMessageFlag, "version-commit-message", "", "Specify the message template for the commit setting the version in version files (overrides config)")
+
 	newCmd.AddCommand(newVersionCmd)
 	newCmd.AddCommand(newMajorCmd)
 	newCmd.AddCommand(newMinorCmd)
 	newCmd.AddCommand(newPatchCmd)
+	newCmd.AddCommand(newAutoCmd)
+	newCmd.AddCommand(newPremajorCmd)
+	newCmd.AddCommand(newPreminorCmd)
+	newCmd.AddCommand(newPrepatchCmd)
+	newCmd.AddCommand(newPrereleaseCmd)
+
+	for _, preCmd := range []*cobra.Command{newPremajorCmd, newPreminorCmd, newPrepatchCmd, newPrereleaseCmd} {
+		preCmd.Flags().StringVar(&PreidFlag, "preid", "rc", "Specify the prerelease identifier (e.g. alpha, beta, rc)")
+	}
 }
 
-func runNewCmd(args []string, ctx interfaces.GitRelContext) {
-	version := args[0]
-	if !semver.ValidateSemver(version) {
-		ctx.Output().Println("invalid version format. please use semantic versioning (e.g., 1.0.0, 1.2.3-alpha, 2.0.0+build.1)")
+func runNewCmd(args []string, from string, ctx interfaces.GitRelContext) error {
+	version, err := semver.Parse(args[0])
+	if err == nil {
+		err = git.CreateRelease(version, from, ctx)
 	}
 
-	git.CreateReleaseBranch(version, ctx)
+	return emitResult("new", version, err, ctx)
 }
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var BumpTypesFlag string
+
+var newAutoCmd = &cobra.Command{
+	Use:   "auto",
+	Short: "Increment the latest release by the most significant Conventional Commit since it",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runNewAutoCmd(FromFlag, ctx)
+	},
+}
+
+func init() {
+	newAutoCmd.Flags().StringVar(&BumpTypesFlag, "bump-types", "", "Specify the part each commit type bumps, e.g. feat=minor,fix=patch,docs=none (overrides config)")
+}
+
+func runNewAutoCmd(from string, ctx interfaces.GitRelContext) error {
+	version, err := git.AutoIncrementAndCreateBranch(from, ctx)
+	return emitResult("new auto", version, err, ctx)
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"gitrel/gitrel_test"
+	"testing"
+)
+
+// newAutoTestContext is on main, with the given commit messages after release
+// 1.1.0, oldest first
+func newAutoTestContext(t *testing.T, messages ...string) *gitrel_test.TestGitRelContext {
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+		"release/1.0.0",
+		"remotes/origin/release/1.0.0",
+		"release/1.1.0",
+		"remotes/origin/release/1.1.0",
+	}
+	ctx.GitContext.Commits = map[string]string{
+		"release/1.0.0": "a100000",
+		"release/1.1.0": "b100000",
+	}
+	ctx.GitContext.Parents = map[string][]string{
+		"b100000": {"a100000"},
+	}
+
+	parent := "b100000"
+	for i, message := range messages {
+		commit := string(rune('c'+i)) + "100000"
+		ctx.GitContext.Parents[commit] = []string{parent}
+		ctx.GitContext.Messages[commit] = message
+		parent = commit
+	}
+
+	ctx.GitContext.Commits["HEAD"] = parent
+	return ctx
+}
+
+func TestRunNewAutoCmd_BumpsMinorForFeature(t *testing.T) {
+	// Arrange
+	ctx := newAutoTestContext(t, "fix: handle empty tags", "feat(list): add --all-remotes", "docs: update README")
+
+	// Act
+	err := runNewAutoCmd("", ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("release/1.2.0"),
+		gitrel_test.EffectCheckoutBranch("release/1.2.0"),
+		gitrel_test.EffectPushBranch("origin", "release/1.2.0"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Commits since 1.1.0:",
+		" - e100000 docs: update README (none)",
+		" - d100000 feat(list): add --all-remotes (minor)",
+		" - c100000 fix: handle empty tags (patch)",
+		"Bumping minor version",
+		"Created new release branch: release/1.2.0",
+		"Pushing release/1.2.0 to origin...",
+		"Pushed!",
+		"Switched back to branch: main",
+	)
+}
+
+func TestRunNewAutoCmd_BumpsMajorForBreakingChange(t *testing.T) {
+	// Arrange
+	ctx := newAutoTestContext(t, "feat: add describe", "refactor: rename flags\n\nBREAKING CHANGE: --ls is now --remote-only", "Tidy up")
+
+	// Act
+	err := runNewAutoCmd("", ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.GitContext.AssertSideEffectContains(gitrel_test.EffectCreateBranch("release/2.0.0"))
+	ctx.OutputContext.AssertOutputLines(
+		"Commits since 1.1.0:",
+		" - e100000 Tidy up (not a conventional commit)",
+		" - d100000 refactor: rename flags (major, breaking change)",
+		" - c100000 feat: add describe (minor)",
+		"Bumping major version",
+		"Created new release branch: release/2.0.0",
+		"Pushing release/2.0.0 to origin...",
+		"Pushed!",
+		"Switched back to branch: main",
+	)
+}
+
+func TestRunNewAutoCmd_UsesCustomBumpTypes(t *testing.T) {
+	// Arrange
+	ctx := newAutoTestContext(t, "docs: update README")
+	ctx.CommandContext.BumpTypes["docs"] = "patch"
+
+	// Act
+	err := runNewAutoCmd("", ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.GitContext.AssertSideEffectContains(gitrel_test.EffectCreateBranch("release/1.1.1"))
+}
+
+func TestRunNewAutoCmd_FailsWhenNoCommitCallsForARelease(t *testing.T) {
+	// Arrange
+	ctx := newAutoTestContext(t, "docs: update README", "chore: bump dependencies")
+
+	// Act
+	err := runNewAutoCmd("", ctx)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"Commits since 1.1.0:",
+		" - d100000 chore: bump dependencies (none)",
+		" - c100000 docs: update README (none)",
+		"none of the commits since 1.1.0 call for a release (bump types: feat=minor,fix=patch,perf=patch)",
+	)
+}
+
+func TestRunNewAutoCmd_FailsWithNoCommitsSinceRelease(t *testing.T) {
+	// Arrange
+	ctx := newAutoTestContext(t)
+
+	// Act
+	err := runNewAutoCmd("", ctx)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"no commits since 1.1.0",
+	)
+}
@@ -17,11 +17,11 @@ var newMajorCmd = &cobra.Command{
 			return err
 		}
 
-		runNewMajorCmd(ctx)
-		return nil	
+		return runNewMajorCmd(FromFlag, ctx)
 	},
 }
 
-func runNewMajorCmd(ctx interfaces.GitRelContext) {
-	git.IncrementAndCreateBranch("major", ctx)
+func runNewMajorCmd(from string, ctx interfaces.GitRelContext) error {
+	version, err := git.IncrementAndCreateBranch("major", from, ctx)
+	return emitResult("new major", version, err, ctx)
 }
@@ -16,7 +16,7 @@ func TestRunNewMajorCmd_IncrementsMajorVersion(t *testing.T) {
 	}
 	ctx.GitContext.CurrentBranch = "main"
 	// Act
-	runNewMajorCmd(ctx)
+	runNewMajorCmd("", ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -42,7 +42,7 @@ func TestRunNewMajorCmd_IncrementsMajorVersion_FromNoPreviousReleases(t *testing
 	}
 	ctx.GitContext.CurrentBranch = "main"
 	// Act
-	runNewMajorCmd(ctx)
+	runNewMajorCmd("", ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -73,7 +73,7 @@ func TestRunNewMajorCmd_IncrementsMajorVersion_WithDifferentLocalBranchNamingCon
 	ctx.GitContext.CurrentBranch = "main"
 
 	// Act
-	runNewMajorCmd(ctx)
+	runNewMajorCmd("", ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
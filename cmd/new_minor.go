@@ -17,11 +17,11 @@ var newMinorCmd = &cobra.Command{
 			return err
 		}
 
-		runNewMinorCmd(ctx)
-		return nil
+		return runNewMinorCmd(FromFlag, ctx)
 	},
 }
 
-func runNewMinorCmd(ctx interfaces.GitRelContext) {
-	git.IncrementAndCreateBranch("minor", ctx)
+func runNewMinorCmd(from string, ctx interfaces.GitRelContext) error {
+	version, err := git.IncrementAndCreateBranch("minor", from, ctx)
+	return emitResult("new minor", version, err, ctx)
 }
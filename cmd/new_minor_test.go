@@ -16,7 +16,7 @@ func TestRunNewMinorCmd_IncrementsMinorVersion(t *testing.T) {
 	}
 	ctx.GitContext.CurrentBranch = "main"
 	// Act
-	runNewMinorCmd(ctx)
+	runNewMinorCmd("", ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -43,7 +43,7 @@ func TestRunNewMinorCmd_IncrementsMinorVersion_FromNoPreviousReleases(t *testing
 	ctx.GitContext.CurrentBranch = "main"
 
 	// Act
-	runNewMinorCmd(ctx)
+	runNewMinorCmd("", ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -72,7 +72,7 @@ func TestRunNewMinorCmd_IncrementsMinorVersion_WithDifferentLocalBranchNamingCon
 	}
 	ctx.GitContext.CurrentBranch = "main"
 	// Act
-	runNewMinorCmd(ctx)
+	runNewMinorCmd("", ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -88,3 +88,103 @@ func TestRunNewMinorCmd_IncrementsMinorVersion_WithDifferentLocalBranchNamingCon
 		"Switched back to branch: main",
 	)
 }
+
+func TestRunNewMinorCmd_FromCommitish_CreatesBranchWithoutSwitching(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+		"release/1.0.0",
+		"remotes/origin/release/1.0.0",
+	}
+	ctx.GitContext.CurrentBranch = "feature/x"
+
+	// Act
+	runNewMinorCmd("origin/main", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranchAt("release/1.1.0", "origin/main"),
+		gitrel_test.EffectPushBranch("origin", "release/1.1.0"),
+	)
+}
+
+func TestRunNewMinorCmd_MinorLines_CreatesLineBranchAndTagsFirstRelease(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Line = "minor"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.4",
+	}
+	ctx.GitContext.Tags = []string{
+		"v1.4.0",
+		"v1.4.1",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runNewMinorCmd("", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("release/1.5"),
+		gitrel_test.EffectCheckoutBranch("release/1.5"),
+		gitrel_test.EffectPushBranch("origin", "release/1.5"),
+		gitrel_test.EffectCheckoutBranch("main"),
+		gitrel_test.EffectCreateTag("v1.5.0", "release/1.5"),
+		gitrel_test.EffectPushTag("origin", "v1.5.0"),
+	)
+}
+
+func TestRunNewMinorCmd_MajorLines_TagsMinorOnExistingLine(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Line = "major"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1",
+	}
+	ctx.GitContext.Tags = []string{
+		"v1.4.1",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runNewMinorCmd("", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateTag("v1.5.0", "release/1"),
+		gitrel_test.EffectPushTag("origin", "v1.5.0"),
+	)
+}
+
+func TestRunNewMinorCmd_RemoteOnly_IncrementsLatestVersionOnRemote(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.RemoteOnly = true
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+		"release/1.0.0",
+		"remotes/origin/release/1.0.0",
+	}
+	ctx.GitContext.RemoteRefs = []string{
+		"refs/heads/main",
+		"refs/heads/release/1.0.0",
+		"refs/heads/release/1.1.0",
+	}
+
+	// Act
+	runNewMinorCmd("", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("release/1.2.0"),
+		gitrel_test.EffectCheckoutBranch("release/1.2.0"),
+		gitrel_test.EffectPushBranch("origin", "release/1.2.0"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+}
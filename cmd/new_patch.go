@@ -17,11 +17,11 @@ var newPatchCmd = &cobra.Command{
 			return err
 		}
 
-		runNewPatchCmd(ctx)
-		return nil
+		return runNewPatchCmd(FromFlag, ctx)
 	},
 }
 
-func runNewPatchCmd(ctx interfaces.GitRelContext) {
-	git.IncrementAndCreateBranch("patch", ctx)
+func runNewPatchCmd(from string, ctx interfaces.GitRelContext) error {
+	version, err := git.IncrementAndCreateBranch("patch", from, ctx)
+	return emitResult("new patch", version, err, ctx)
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"gitrel/gitrel_test"
+	"gitrel/versionfile"
 	"testing"
 )
 
@@ -17,7 +18,7 @@ func TestRunNewPatchCmd_IncrementsPatchVersion(t *testing.T) {
 	ctx.GitContext.CurrentBranch = "main"
 
 	// Act
-	runNewPatchCmd(ctx)
+	runNewPatchCmd("", ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -44,7 +45,7 @@ func TestRunNewPatchCmd_IncrementsPatchVersion_FromNoPreviousReleases(t *testing
 	ctx.GitContext.CurrentBranch = "main"
 
 	// Act
-	runNewPatchCmd(ctx)
+	runNewPatchCmd("", ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -74,7 +75,7 @@ func TestRunNewPatchCmd_IncrementsPatchVersion_WithDifferentLocalBranchNamingCon
 	ctx.GitContext.CurrentBranch = "main"
 	
 	// Act
-	runNewPatchCmd(ctx)
+	runNewPatchCmd("", ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -90,3 +91,111 @@ func TestRunNewPatchCmd_IncrementsPatchVersion_WithDifferentLocalBranchNamingCon
 		"Switched back to branch: main",
 	)
 }
+
+func TestRunNewPatchCmd_TagsMode_IncrementsLatestTag(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Mode = "tags"
+	ctx.GitContext.Tags = []string{
+		"v1.0.0",
+		"v1.0.1",
+	}
+
+	// Act
+	runNewPatchCmd("", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateTag("v1.0.2", ""),
+		gitrel_test.EffectPushTag("origin", "v1.0.2"),
+	)
+}
+
+func TestRunNewPatchCmd_MinorLines_TagsNextPatchOnLineBranch(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Line = "minor"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.3",
+		"release/1.4",
+		"remotes/origin/release/1.4",
+	}
+	ctx.GitContext.Tags = []string{
+		"v1.3.7",
+		"v1.4.0",
+		"v1.4.1",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runNewPatchCmd("", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateTag("v1.4.2", "release/1.4"),
+		gitrel_test.EffectPushTag("origin", "v1.4.2"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Created new release tag: v1.4.2 (from release/1.4)",
+		"Pushing tag v1.4.2 to origin...",
+		"Pushed!",
+	)
+}
+
+func TestRunNewPatchCmd_MinorLines_CommitsVersionFilesOnLineBranchBeforeTagging(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Line = "minor"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/release/1.4",
+	}
+	ctx.GitContext.Tags = []string{
+		"v1.4.0",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+	ctx.GitContext.Files = map[string]string{"VERSION": "1.4.0\n"}
+	ctx.CommandContext.VersionFiles = []versionfile.File{
+		{Path: "VERSION", Format: versionfile.FormatRegex, Expression: `^\S+`},
+	}
+
+	// Act
+	runNewPatchCmd("", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranchAt("release/1.4", "remotes/origin/release/1.4"),
+		gitrel_test.EffectCommitFiles("release/1.4", "Bump version to 1.4.1", "VERSION"),
+		gitrel_test.EffectPushBranch("origin", "release/1.4"),
+		gitrel_test.EffectCreateTag("v1.4.1", "release/1.4"),
+		gitrel_test.EffectPushTag("origin", "v1.4.1"),
+	)
+}
+
+func TestRunNewPatchCmd_MinorLines_VersionFilesFromCommitish_Fails(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Line = "minor"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.4",
+	}
+	ctx.GitContext.Tags = []string{
+		"v1.4.0",
+	}
+	ctx.GitContext.Files = map[string]string{"VERSION": "1.4.0\n"}
+	ctx.CommandContext.VersionFiles = []versionfile.File{
+		{Path: "VERSION", Format: versionfile.FormatRegex, Expression: `^\S+`},
+	}
+
+	// Act
+	err := runNewPatchCmd("abc123", ctx)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	ctx.GitContext.AssertNoSideEffects()
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var newPremajorCmd = &cobra.Command{
+	Use:   "premajor",
+	Short: "Create a prerelease of the next major version",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runNewPremajorCmd(PreidFlag, FromFlag, ctx)
+	},
+}
+
+func runNewPremajorCmd(preid string, from string, ctx interfaces.GitRelContext) error {
+	version, err := git.IncrementPrereleaseAndCreateBranch("premajor", preid, from, ctx)
+	return emitResult("new premajor", version, err, ctx)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"gitrel/gitrel_test"
+	"testing"
+)
+
+func TestRunNewPremajorCmd_CreatesPrereleaseOfNextMajorVersion(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+		"release/1.2.3",
+		"remotes/origin/release/1.2.3",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runNewPremajorCmd("rc", "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("release/2.0.0-rc.1"),
+		gitrel_test.EffectCheckoutBranch("release/2.0.0-rc.1"),
+		gitrel_test.EffectPushBranch("origin", "release/2.0.0-rc.1"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Created new release branch: release/2.0.0-rc.1",
+		"Pushing release/2.0.0-rc.1 to origin...",
+		"Pushed!",
+		"Switched back to branch: main",
+	)
+}
+
+func TestRunNewPremajorCmd_FromNoPreviousReleases(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runNewPremajorCmd("beta", "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("release/1.0.0-beta.1"),
+		gitrel_test.EffectCheckoutBranch("release/1.0.0-beta.1"),
+		gitrel_test.EffectPushBranch("origin", "release/1.0.0-beta.1"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var newPreminorCmd = &cobra.Command{
+	Use:   "preminor",
+	Short: "Create a prerelease of the next minor version",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runNewPreminorCmd(PreidFlag, FromFlag, ctx)
+	},
+}
+
+func runNewPreminorCmd(preid string, from string, ctx interfaces.GitRelContext) error {
+	version, err := git.IncrementPrereleaseAndCreateBranch("preminor", preid, from, ctx)
+	return emitResult("new preminor", version, err, ctx)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"gitrel/gitrel_test"
+	"testing"
+)
+
+func TestRunNewPreminorCmd_CreatesPrereleaseOfNextMinorVersion(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+		"release/1.2.3",
+		"remotes/origin/release/1.2.3",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runNewPreminorCmd("rc", "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("release/1.3.0-rc.1"),
+		gitrel_test.EffectCheckoutBranch("release/1.3.0-rc.1"),
+		gitrel_test.EffectPushBranch("origin", "release/1.3.0-rc.1"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Created new release branch: release/1.3.0-rc.1",
+		"Pushing release/1.3.0-rc.1 to origin...",
+		"Pushed!",
+		"Switched back to branch: main",
+	)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var newPrepatchCmd = &cobra.Command{
+	Use:   "prepatch",
+	Short: "Create a prerelease of the next patch version",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runNewPrepatchCmd(PreidFlag, FromFlag, ctx)
+	},
+}
+
+func runNewPrepatchCmd(preid string, from string, ctx interfaces.GitRelContext) error {
+	version, err := git.IncrementPrereleaseAndCreateBranch("prepatch", preid, from, ctx)
+	return emitResult("new prepatch", version, err, ctx)
+}
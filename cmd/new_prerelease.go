@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var newPrereleaseCmd = &cobra.Command{
+	Use:   "prerelease",
+	Short: "Increment the prerelease counter of the latest release",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runNewPrereleaseCmd(PreidFlag, FromFlag, ctx)
+	},
+}
+
+func runNewPrereleaseCmd(preid string, from string, ctx interfaces.GitRelContext) error {
+	version, err := git.IncrementPrereleaseAndCreateBranch("prerelease", preid, from, ctx)
+	return emitResult("new prerelease", version, err, ctx)
+}
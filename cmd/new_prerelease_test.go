@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"gitrel/gitrel_test"
+	"testing"
+)
+
+func TestRunNewPrereleaseCmd_IncrementsPrereleaseCounter(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+		"release/1.2.3",
+		"remotes/origin/release/1.2.3",
+		"release/2.0.0-rc.1",
+		"remotes/origin/release/2.0.0-rc.1",
+		"remotes/origin/release/2.0.0-rc.2",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runNewPrereleaseCmd("rc", "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("release/2.0.0-rc.3"),
+		gitrel_test.EffectCheckoutBranch("release/2.0.0-rc.3"),
+		gitrel_test.EffectPushBranch("origin", "release/2.0.0-rc.3"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Created new release branch: release/2.0.0-rc.3",
+		"Pushing release/2.0.0-rc.3 to origin...",
+		"Pushed!",
+		"Switched back to branch: main",
+	)
+}
+
+func TestRunNewPrereleaseCmd_StartsNewCounterForDifferentPreid(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/2.0.0-beta.4",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runNewPrereleaseCmd("rc", "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("release/2.0.0-rc.1"),
+		gitrel_test.EffectCheckoutBranch("release/2.0.0-rc.1"),
+		gitrel_test.EffectPushBranch("origin", "release/2.0.0-rc.1"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+}
+
+func TestRunNewPrereleaseCmd_StartsPrepatchFromFinalRelease(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/2.0.0-rc.2",
+		"release/2.0.0",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runNewPrereleaseCmd("rc", "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("release/2.0.1-rc.1"),
+		gitrel_test.EffectCheckoutBranch("release/2.0.1-rc.1"),
+		gitrel_test.EffectPushBranch("origin", "release/2.0.1-rc.1"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+}
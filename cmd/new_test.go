@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"gitrel/gitrel_test"
+	"gitrel/versionfile"
 	"testing"
 )
 
@@ -15,7 +16,7 @@ func TestRunNewCmd_CreatesNewReleaseBranch(t *testing.T) {
 	ctx.GitContext.CurrentBranch = "main"
 
 	// Act
-	runNewCmd([]string{"1.0.0"}, ctx)
+	runNewCmd([]string{"1.0.0"}, "", ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -42,7 +43,7 @@ func TestRunNewCmd_WithPrereleaseVersion(t *testing.T) {
 	ctx.GitContext.CurrentBranch = "main"
 
 	// Act
-	runNewCmd([]string{"1.0.0-beta.1"}, ctx)
+	runNewCmd([]string{"1.0.0-beta.1"}, "", ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -69,7 +70,7 @@ func TestRunNewCmd_WithBuildMetadata(t *testing.T) {
 	ctx.GitContext.CurrentBranch = "main"
 
 	// Act
-	runNewCmd([]string{"1.0.0+build.1"}, ctx)
+	runNewCmd([]string{"1.0.0+build.1"}, "", ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -96,11 +97,348 @@ func TestRunNewCmd_WithInvalidVersion(t *testing.T) {
 	ctx.GitContext.CurrentBranch = "main"
 
 	// Act
-	runNewCmd([]string{"invalid-version"}, ctx)
+	runNewCmd([]string{"invalid-version"}, "", ctx)
 
 	// Assert
 	ctx.GitContext.AssertNoSideEffects()
 	ctx.OutputContext.AssertOutputLines(
 		"invalid version format. please use semantic versioning (e.g., 1.0.0, 1.2.3-alpha, 2.0.0+build.1)",
 	)
-}
\ No newline at end of file
+}
+
+func TestRunNewCmd_FromCommitish_CreatesBranchWithoutSwitching(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+	ctx.GitContext.HasUncommittedChangesFl = true
+
+	// Act
+	runNewCmd([]string{"1.0.0"}, "v0.9.0~2", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranchAt("release/1.0.0", "v0.9.0~2"),
+		gitrel_test.EffectPushBranch("origin", "release/1.0.0"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Created new release branch: release/1.0.0 (from v0.9.0~2)",
+		"Pushing release/1.0.0 to origin...",
+		"Pushed!",
+	)
+}
+
+func TestRunNewCmd_FromCommitish_PrintsErrorWhenBranchExists(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.0.0",
+	}
+
+	// Act
+	runNewCmd([]string{"1.0.0"}, "abc1234", ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"branch release/1.0.0 already exists",
+	)
+}
+
+func TestRunNewCmd_TagsMode_CreatesAndPushesTag(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Mode = "tags"
+	ctx.GitContext.Tags = []string{"v0.9.0"}
+
+	// Act
+	runNewCmd([]string{"1.0.0"}, "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateTag("v1.0.0", ""),
+		gitrel_test.EffectPushTag("origin", "v1.0.0"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Created new release tag: v1.0.0",
+		"Pushing tag v1.0.0 to origin...",
+		"Pushed!",
+	)
+}
+
+func TestRunNewCmd_TagsMode_PrintsErrorWhenTagExists(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Mode = "tags"
+	ctx.GitContext.Tags = []string{"v1.0.0"}
+
+	// Act
+	runNewCmd([]string{"1.0.0"}, "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"tag v1.0.0 already exists",
+	)
+}
+
+func TestRunNewCmd_BothMode_CreatesBranchAndTag(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Mode = "both"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runNewCmd([]string{"1.0.0"}, "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("release/1.0.0"),
+		gitrel_test.EffectCheckoutBranch("release/1.0.0"),
+		gitrel_test.EffectPushBranch("origin", "release/1.0.0"),
+		gitrel_test.EffectCheckoutBranch("main"),
+		gitrel_test.EffectCreateTag("v1.0.0", "release/1.0.0"),
+		gitrel_test.EffectPushTag("origin", "v1.0.0"),
+	)
+}
+
+func TestRunNewCmd_TagOnRelease_TagsAndPushesNewBranch(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.TagOnRelease = "v%v+%n"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runNewCmd([]string{"1.0.0"}, "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("release/1.0.0"),
+		gitrel_test.EffectCheckoutBranch("release/1.0.0"),
+		gitrel_test.EffectPushBranch("origin", "release/1.0.0"),
+		gitrel_test.EffectCreateTag("v1.0.0+1", "release/1.0.0"),
+		gitrel_test.EffectPushTag("origin", "v1.0.0+1"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Created new release branch: release/1.0.0",
+		"Pushing release/1.0.0 to origin...",
+		"Pushed!",
+		"Tagged release/1.0.0 as v1.0.0+1",
+		"Pushing tag v1.0.0+1 to origin...",
+		"Pushed!",
+		"Switched back to branch: main",
+	)
+}
+
+func TestRunNewCmd_RendersNamedPlaceholders(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Component = "api"
+	ctx.CommandContext.LocalBranchName = "{component}/rel-{major}.{minor}.{patch}-stable"
+	ctx.CommandContext.RemoteBranchName = "{component}/release/{version}"
+	ctx.GitContext.Branches = []string{
+		"main",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runNewCmd([]string{"1.2.0"}, "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("api/rel-1.2.0-stable"),
+		gitrel_test.EffectCheckoutBranch("api/rel-1.2.0-stable"),
+		gitrel_test.EffectPushBranch("origin", "api/rel-1.2.0-stable:api/release/1.2.0"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+}
+
+func TestRunNewCmd_KeepsPrereleaseAndBuildInNamedPlaceholders(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.LocalBranchName = "rel-{major}.{minor}.{patch}"
+	ctx.CommandContext.RemoteBranchName = "release/{major}.{minor}.{patch}"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"rel-1.2.0",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runNewCmd([]string{"1.2.0-rc.1+build.7"}, "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("rel-1.2.0-rc.1+build.7"),
+		gitrel_test.EffectCheckoutBranch("rel-1.2.0-rc.1+build.7"),
+		gitrel_test.EffectPushBranch("origin", "rel-1.2.0-rc.1+build.7:release/1.2.0-rc.1+build.7"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+}
+
+func TestRunNewCmd_RemoteOnly_FailsWhenVersionExistsOnRemote(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.RemoteOnly = true
+	ctx.GitContext.RemoteRefs = []string{
+		"refs/heads/main",
+		"refs/heads/release/3.0.0",
+	}
+
+	// Act
+	runNewCmd([]string{"3.0.0"}, "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"branch release/3.0.0 already exists",
+	)
+}
+
+func TestRunNewCmd_VersionFiles_CommitsVersionBeforePushing(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+	ctx.GitContext.Files = map[string]string{
+		"package.json":     "{\n  \"version\": \"0.9.0\"\n}\n",
+		"chart/Chart.yaml": "version: 0.9.0\nappVersion: \"0.9.0\"\n",
+	}
+	ctx.CommandContext.VersionFiles = []versionfile.File{
+		{Path: "package.json", Format: versionfile.FormatJSON, Expression: "version"},
+		{Path: "chart/Chart.yaml", Format: versionfile.FormatYAML, Expression: "appVersion"},
+	}
+
+	// Act
+	err := runNewCmd([]string{"1.0.0"}, "", ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranchAt("release/1.0.0", "HEAD"),
+		gitrel_test.EffectCommitFiles("release/1.0.0", "Bump version to 1.0.0", "chart/Chart.yaml", "package.json"),
+		gitrel_test.EffectPushBranch("origin", "release/1.0.0"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Created new release branch: release/1.0.0",
+		"Set version in package.json to 1.0.0",
+		"Set version in chart/Chart.yaml to 1.0.0",
+		"Committed version files to release/1.0.0",
+		"Pushing release/1.0.0 to origin...",
+		"Pushed!",
+	)
+
+	expectedFiles := map[string]string{
+		"package.json":     "{\n  \"version\": \"1.0.0\"\n}\n",
+		"chart/Chart.yaml": "version: 0.9.0\nappVersion: \"1.0.0\"\n",
+	}
+	for path, expected := range expectedFiles {
+		if ctx.GitContext.CommittedFiles[path] != expected {
+			t.Errorf("expected %s to be committed as %q, got %q", path, expected, ctx.GitContext.CommittedFiles[path])
+		}
+	}
+}
+
+func TestRunNewCmd_VersionFiles_FromCommitish_ReadsFilesThere(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+	}
+	ctx.GitContext.Files = map[string]string{"VERSION": "0.9.0\n"}
+	ctx.CommandContext.VersionFiles = []versionfile.File{
+		{Path: "VERSION", Format: versionfile.FormatRegex, Expression: `^\S+`},
+	}
+	ctx.CommandContext.VersionCommitMessage = "chore: release %v"
+
+	// Act
+	runNewCmd([]string{"1.0.0"}, "v0.9.0~2", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranchAt("release/1.0.0", "v0.9.0~2"),
+		gitrel_test.EffectCommitFiles("release/1.0.0", "chore: release 1.0.0", "VERSION"),
+		gitrel_test.EffectPushBranch("origin", "release/1.0.0"),
+	)
+}
+
+func TestRunNewCmd_VersionFiles_SkipsCommitWhenVersionIsSet(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+	}
+	ctx.GitContext.Files = map[string]string{"version.go": "package main\n\nconst Version = \"1.0.0\"\n"}
+	ctx.CommandContext.VersionFiles = []versionfile.File{
+		{Path: "version.go", Format: versionfile.FormatGo, Expression: "Version"},
+	}
+
+	// Act
+	runNewCmd([]string{"1.0.0"}, "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranchAt("release/1.0.0", "HEAD"),
+		gitrel_test.EffectPushBranch("origin", "release/1.0.0"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Created new release branch: release/1.0.0",
+		"Version in version.go is already 1.0.0",
+		"Pushing release/1.0.0 to origin...",
+		"Pushed!",
+	)
+}
+
+func TestRunNewCmd_VersionFiles_RemovesBranchWhenFileCannotBeUpdated(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+	}
+	ctx.GitContext.Files = map[string]string{"package.json": "{\"name\": \"gitrel\"}\n"}
+	ctx.CommandContext.VersionFiles = []versionfile.File{
+		{Path: "package.json", Format: versionfile.FormatJSON, Expression: "version"},
+		{Path: "Cargo.toml", Format: versionfile.FormatTOML, Expression: "package.version"},
+	}
+
+	// Act
+	err := runNewCmd([]string{"1.0.0"}, "", ctx)
+
+	// Assert
+	if code := exitCode(err); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranchAt("release/1.0.0", "HEAD"),
+		gitrel_test.EffectDeleteBranch("release/1.0.0"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Created new release branch: release/1.0.0",
+		"Rolling back new 1.0.0...",
+		"  git branch -D release/1.0.0",
+		"error updating version in package.json: no value at version",
+	)
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+	"gitrel/semver"
+
+	"github.com/spf13/cobra"
+)
+
+var TemplateFlag string
+
+var notesCmd = &cobra.Command{
+	Use:   "notes <version>",
+	Short: "Render the release notes for a release from a template",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runNotesCmd(args, ctx)
+	},
+}
+
+func init() {
+	notesCmd.Flags().StringVar(&TemplateFlag, "template", "", "Specify a text/template file to render the release notes with (overrides config)")
+}
+
+func runNotesCmd(args []string, ctx interfaces.GitRelContext) error {
+	notes, err := git.GetReleaseNotes(args[0], ctx)
+	if err != nil {
+		return emitResult("notes", semver.Version{}, err, ctx)
+	}
+
+	ctx.Output().Emit(notes)
+	return nil
+}
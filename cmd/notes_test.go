@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunNotesCmd_RendersDefaultTemplate(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	ctx.GitContext.Messages["c100000"] = "fix(list): handle empty tags\n\nFixes #42 and GITREL-7"
+	ctx.GitContext.Messages["c300000"] = "feat!: rename --ls to --remote-only (GITREL-7)"
+	ctx.GitContext.Authors = map[string]string{
+		"c100000": "Ada",
+		"c200000": "Grace",
+		"c300000": "Ada",
+		"c400000": "Linus",
+	}
+
+	// Act
+	err := runNotesCmd([]string{"1.2"}, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"# 1.2.0 (2026-10-18)",
+		"",
+		"Changes since 1.1.0.",
+		"",
+		"## Added",
+		"",
+		"- **BREAKING:** rename --ls to --remote-only (GITREL-7) (c300000)",
+		"",
+		"## Fixed",
+		"",
+		"- **list:** handle empty tags (c100000)",
+		"",
+		"## Other",
+		"",
+		"- Tidy up (c400000)",
+		"",
+		"## Issues",
+		"",
+		"#42, GITREL-7",
+		"",
+		"## Contributors",
+		"",
+		"- Ada",
+		"- Grace",
+		"- Linus",
+	)
+}
+
+func TestRunNotesCmd_RendersTemplateFile(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	ctx.CommandContext.NotesTemplate = filepath.Join(t.TempDir(), "notes.tmpl")
+	template := "Release {{.Version}} on {{.Date}} after {{.Previous}}\n" +
+		"{{range .Groups}}{{.Title}}: {{len .Entries}}\n{{end}}"
+	err := os.WriteFile(ctx.CommandContext.NotesTemplate, []byte(template), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	err = runNotesCmd([]string{"1.1"}, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx.OutputContext.AssertOutputLines(
+		"Release 1.1.0 on 2026-09-01 after 1.0.0",
+		"Added: 1",
+	)
+}
+
+func TestRunNotesCmd_FailsOnInvalidTemplate(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	ctx.CommandContext.NotesTemplate = filepath.Join(t.TempDir(), "notes.tmpl")
+	err := os.WriteFile(ctx.CommandContext.NotesTemplate, []byte("{{.Missing}}"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	err = runNotesCmd([]string{"latest"}, ctx)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestRunNotesCmd_FailsOnMissingTemplate(t *testing.T) {
+	// Arrange
+	ctx := changelogTestContext(t)
+	ctx.CommandContext.NotesTemplate = filepath.Join(t.TempDir(), "missing.tmpl")
+
+	// Act
+	err := runNotesCmd([]string{"latest"}, ctx)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+
+	"github.com/spf13/cobra"
+)
+
+var promoteCmd = &cobra.Command{
+	Use:   "promote <version>",
+	Short: "Create the final release branch from the latest prerelease of a version",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runPromoteCmd(args, ctx)
+	},
+}
+
+func runPromoteCmd(args []string, ctx interfaces.GitRelContext) error {
+	version, err := git.PromoteVersion(args[0], ctx)
+	return emitResult("promote", version, err, ctx)
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"gitrel/gitrel_test"
+	"testing"
+)
+
+func TestRunPromoteCmd_CreatesReleaseFromLatestPrerelease(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.2.3",
+		"release/2.0.0-rc.1",
+		"release/2.0.0-rc.2",
+		"release/2.1.0-rc.1",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runPromoteCmd([]string{"2.0.0"}, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranchAt("release/2.0.0", "release/2.0.0-rc.2"),
+		gitrel_test.EffectPushBranch("origin", "release/2.0.0"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Promoting 2.0.0-rc.2 to 2.0.0...",
+		"Created new release branch: release/2.0.0 (from release/2.0.0-rc.2)",
+		"Pushing release/2.0.0 to origin...",
+		"Pushed!",
+	)
+}
+
+func TestRunPromoteCmd_PromotesSpecifiedPrerelease(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/2.0.0-rc.1",
+		"release/2.0.0-rc.2",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runPromoteCmd([]string{"2.0.0-rc.1"}, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranchAt("release/2.0.0", "release/2.0.0-rc.1"),
+		gitrel_test.EffectPushBranch("origin", "release/2.0.0"),
+	)
+}
+
+func TestRunPromoteCmd_UsesRemotePrereleaseBranch_WhenNoLocalBranch(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/release/2.0.0-rc.1",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runPromoteCmd([]string{"2.0.0"}, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranchAt("release/2.0.0", "remotes/origin/release/2.0.0-rc.1"),
+		gitrel_test.EffectPushBranch("origin", "release/2.0.0"),
+	)
+}
+
+func TestRunPromoteCmd_PrintsErrorWhenReleaseAlreadyExists(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/2.0.0-rc.1",
+		"release/2.0.0",
+	}
+
+	// Act
+	runPromoteCmd([]string{"2.0.0"}, ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"Promoting 2.0.0-rc.1 to 2.0.0...",
+		"branch release/2.0.0 already exists",
+	)
+}
+
+func TestRunPromoteCmd_PrintsErrorWhenNoPrereleaseExists(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.0.0",
+	}
+
+	// Act
+	runPromoteCmd([]string{"2.0.0"}, ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"no prerelease found for version: 2.0.0",
+	)
+}
+
+func TestRunPromoteCmd_TagsMode_TagsFinalReleaseAtPrereleaseTag(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Mode = "tags"
+	ctx.GitContext.Tags = []string{
+		"v2.0.0-rc.1",
+		"v2.0.0-rc.2",
+	}
+
+	// Act
+	runPromoteCmd([]string{"2.0.0"}, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateTag("v2.0.0", "v2.0.0-rc.2"),
+		gitrel_test.EffectPushTag("origin", "v2.0.0"),
+	)
+}
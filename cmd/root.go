@@ -1,43 +1,101 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"gitrel/utils"
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
 	RemoteFlag string
 	FetchFlag  bool
 	NoFetchFlag  bool
+	RemoteOnlyFlag bool
 	LocalBranchNameFlag string
 	RemoteBranchNameFlag string
+	ModeFlag string
+	LineFlag string
+	ComponentFlag string
+	TagNameFlag string
+	TagOnReleaseFlag string
+	TagMessageFlag string
+	DryRunFlag bool
+	OutputFlag string
+	BackendFlag string
+	ChangelogFileFlag string
+	ChangelogPatternFlag string
+	IssuePatternFlag string
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "gitrel",
 	Short: "A tool to manage git release branches",
+	// Errors are reported by Execute, or by the command as part of its result
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
+// Execute runs the command line, exiting with a code that says why it failed
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	reportDryRunPlan()
+	if err == nil {
+		return
+	}
+
+	var reported *reportedError
+	if !errors.As(err, &reported) {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
 	}
+
+	os.Exit(exitCode(err))
 }
 
 func init() {
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return utils.WithKind(errUsage, err)
+	})
+
 	rootCmd.PersistentFlags().StringVar(&RemoteFlag, "remote", "", "Specify the git remote name (overrides config)")
 	rootCmd.PersistentFlags().BoolVar(&FetchFlag, "fetch", false, "Fetch from remote before listing branches")
 	rootCmd.PersistentFlags().BoolVar(&NoFetchFlag, "no-fetch", false, "Do not fetch from remote before listing branches")
 	rootCmd.PersistentFlags().StringVar(&LocalBranchNameFlag, "local-branch-name", "", "Specify the local branch name (overrides config)")
 	rootCmd.PersistentFlags().StringVar(&RemoteBranchNameFlag, "remote-branch-name", "", "Specify the remote branch name (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&ModeFlag, "mode", "", "Specify whether releases are branches, tags or both (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&LineFlag, "line", "", "Specify the release line granularity of branches: major, minor or patch (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&ComponentFlag, "component", "", "Specify the component name for the {component} placeholder (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&TagNameFlag, "tag-name", "", "Specify the release tag name (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&TagOnReleaseFlag, "tag-on-release", "", "Tag each push to a release branch using this template, e.g. v%v+%n (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&TagMessageFlag, "tag-message", "", "Specify the message template for tags created on release (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&ChangelogFileFlag, "changelog-file", "", "Add each release created by new or pushed by update to this Keep a Changelog file (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&ChangelogPatternFlag, "changelog-pattern", "", "Group changelog entries by the first group of this regular expression instead of by Conventional Commit type (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&IssuePatternFlag, "issue-pattern", "", "Find issue keys in commit messages with this regular expression instead of the default (overrides config)")
+	rootCmd.PersistentFlags().StringVar(&BackendFlag, "backend", "", "Specify how git is accessed: exec runs the git command, native reads the repository directly (overrides config)")
+	rootCmd.PersistentFlags().StringVarP(&OutputFlag, "output", "o", OutputText, "Output format: text, json or yaml")
+	rootCmd.PersistentFlags().BoolVar(&DryRunFlag, "dry-run", false, "Show the git commands that would be run, without changing anything")
+
+	for _, flags := range []*pflag.FlagSet{listCmd.Flags(), statusCmd.Flags(), newCmd.PersistentFlags()} {
+		flags.BoolVar(&RemoteOnlyFlag, "remote-only", false, "Read releases from the remote with git ls-remote instead of from local and remote tracking branches, without fetching")
+		flags.BoolVar(&RemoteOnlyFlag, "ls-remote", false, "Same as --remote-only")
+	}
 
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(newCmd)
 	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(describeCmd)
+	rootCmd.AddCommand(changelogCmd)
+	rootCmd.AddCommand(notesCmd)
+	rootCmd.AddCommand(logCmd)
+	rootCmd.AddCommand(diffCmd)
 	rootCmd.AddCommand(checkoutCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(pushCmd)
+	rootCmd.AddCommand(promoteCmd)
+	rootCmd.AddCommand(cascadeCmd)
+	rootCmd.AddCommand(backportCmd)
+	rootCmd.AddCommand(undoCmd)
 }
\ No newline at end of file
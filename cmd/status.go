@@ -3,6 +3,7 @@ package cmd
 import (
 	"gitrel/git"
 	"gitrel/interfaces"
+	"gitrel/semver"
 
 	"github.com/spf13/cobra"
 )
@@ -16,11 +17,15 @@ var statusCmd = &cobra.Command{
 			return err
 		}
 
-		runStatusCmd(ctx)
-		return nil
+		return runStatusCmd(ctx)
 	},
 }
 
-func runStatusCmd(ctx interfaces.GitRelContext) {
-	git.ShowStatus(ctx)
+func runStatusCmd(ctx interfaces.GitRelContext) error {
+	err := git.ShowStatus(ctx)
+	if err != nil {
+		return emitResult("status", semver.Version{}, err, ctx)
+	}
+
+	return nil
 }
@@ -236,3 +236,68 @@ func TestRunStatusCmd_ShowsErrorWhenNoReleasesExist(t *testing.T) {
 		"Remote: origin",
 	)
 }
+
+func TestRunStatusCmd_TagsMode_ShowsCurrentVersionFromTagAtHead(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Mode = "tags"
+	ctx.GitContext.Tags = []string{
+		"v1.0.0",
+		"v1.1.0",
+		"v2.0.0",
+	}
+	ctx.GitContext.TagsAtHead = []string{"v1.1.0"}
+	ctx.GitContext.CurrentBranch = "HEAD"
+
+	// Act
+	runStatusCmd(ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"Current version: 1.1.0",
+		"Latest version: 2.0.0",
+		"Remote: origin",
+		"Other versions:",
+		" - 2.0.0 (latest)",
+		" - 1.1.0 (current)",
+		" - 1.0.0",
+	)
+}
+
+func TestRunStatusCmd_MinorLines_ShowsLinesWithLatestPatch(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Line = "minor"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.3",
+		"release/1.4",
+		"remotes/origin/release/1.5",
+	}
+	ctx.GitContext.Tags = []string{
+		"v1.3.0",
+		"v1.3.7",
+		"v1.4.0",
+		"v1.4.2",
+		"v1.4.1",
+		"v1.5.0",
+		"v0.9.0",
+	}
+	ctx.GitContext.CurrentBranch = "release/1.4"
+
+	// Act
+	runStatusCmd(ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"Current version: 1.4.2",
+		"Latest version: 1.5.0",
+		"Remote: origin",
+		"Other versions:",
+		" - 1.5 (1.5.0, latest)",
+		" - 1.4 (1.4.2, current)",
+		" - 1.3 (1.3.7)",
+	)
+}
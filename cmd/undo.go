@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"gitrel/git"
+	"gitrel/interfaces"
+	"gitrel/semver"
+
+	"github.com/spf13/cobra"
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the last new, promote or update",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := NewCmdGitRelContext()
+		if err != nil {
+			return err
+		}
+
+		return runUndoCmd(ctx)
+	},
+}
+
+func runUndoCmd(ctx interfaces.GitRelContext) error {
+	err := git.UndoLastOperation(ctx)
+	return emitResult("undo", semver.Version{}, err, ctx)
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"gitrel/gitrel_test"
+	"testing"
+)
+
+func TestRunNewCmd_RollsBackWhenPushFails(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+	ctx.GitContext.RejectedPushes = []string{"release/1.0.0"}
+
+	// Act
+	runNewCmd([]string{"1.0.0"}, "", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranch("release/1.0.0"),
+		gitrel_test.EffectCheckoutBranch("release/1.0.0"),
+		gitrel_test.EffectCheckoutBranch("main"),
+		gitrel_test.EffectDeleteBranch("release/1.0.0"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Created new release branch: release/1.0.0",
+		"Pushing release/1.0.0 to origin...",
+		"Rolling back new 1.0.0...",
+		"  git checkout main",
+		"  git branch -D release/1.0.0",
+		"push of release/1.0.0:release/1.0.0 to origin rejected",
+	)
+}
+
+func TestRunNewCmd_RollsBackTagWhenBranchPushFails(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.TagOnRelease = "v%v"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+		"remotes/origin/release/1.0.0",
+	}
+	ctx.GitContext.RejectedPushes = []string{"release/1.1.0"}
+
+	// Act
+	runNewCmd([]string{"1.1.0"}, "release/1.0.0", ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCreateBranchAt("release/1.1.0", "release/1.0.0"),
+		gitrel_test.EffectDeleteBranch("release/1.1.0"),
+	)
+}
+
+func TestRunUndoCmd_UndoesLastNewRelease(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/main",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+	runNewCmd([]string{"1.0.0"}, "", ctx)
+
+	ctx.GitContext.SideEffects = []gitrel_test.TestGitSideEffect{}
+	ctx.OutputContext.Output = ""
+
+	// Act
+	runUndoCmd(ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCheckoutBranch("release/1.0.0"),
+		gitrel_test.EffectDeleteRemoteRef("origin", "refs/heads/release/1.0.0"),
+		gitrel_test.EffectCheckoutBranch("main"),
+		gitrel_test.EffectDeleteBranch("release/1.0.0"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Undoing new 1.0.0...",
+		"  git checkout release/1.0.0",
+		"  git push origin --delete refs/heads/release/1.0.0",
+		"  git checkout main",
+		"  git branch -D release/1.0.0",
+		"Undone!",
+	)
+	if ctx.GitContext.State["undo"] != nil {
+		t.Fatal("expected undo state to be cleared")
+	}
+}
+
+func TestRunUndoCmd_UndoesLastUpdate(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+
+	ctx.GitContext.SideEffects = []gitrel_test.TestGitSideEffect{}
+	ctx.OutputContext.Output = ""
+
+	// Act
+	runUndoCmd(ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCheckoutBranch("release/2.0.0"),
+		gitrel_test.EffectForcePushBranch("origin", "remotes/origin/release/2.0.0:refs/heads/release/2.0.0"),
+		gitrel_test.EffectResetBranch("release/2.0.0"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+}
+
+func TestRunUndoCmd_UndoesContinuedUpdate(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.ConflictingBranches = []string{"release/2.0.0"}
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+	runUpdateCmd([]string{}, true, false, ctx)
+
+	ctx.GitContext.SideEffects = []gitrel_test.TestGitSideEffect{}
+	ctx.OutputContext.Output = ""
+
+	// Act
+	runUndoCmd(ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCheckoutBranch("release/2.0.0"),
+		gitrel_test.EffectForcePushBranch("origin", "remotes/origin/release/2.0.0:refs/heads/release/2.0.0"),
+		gitrel_test.EffectResetBranch("release/2.0.0"),
+		gitrel_test.EffectResetBranch("release/2.0.0"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+	if ctx.GitContext.CurrentBranch != "main" {
+		t.Fatalf("expected undo to switch back to main, got %s", ctx.GitContext.CurrentBranch)
+	}
+}
+
+func TestRunUndoCmd_RefusesWhenRepositoryHasMoved(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+
+	ctx.GitContext.CurrentBranch = "release/1.1.1"
+	ctx.GitContext.SideEffects = []gitrel_test.TestGitSideEffect{}
+	ctx.OutputContext.Output = ""
+
+	// Act
+	runUndoCmd(ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"the repository has changed since 'update 2.0.0', so it cannot be undone",
+	)
+}
+
+func TestRunUndoCmd_PrintsErrorWhenNothingToUndo(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+
+	// Act
+	runUndoCmd(ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines("nothing to undo")
+}
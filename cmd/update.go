@@ -3,22 +3,27 @@ package cmd
 import (
 	"gitrel/git"
 	"gitrel/interfaces"
+	"gitrel/semver"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	UpdateContinueFlag bool
+	UpdateAbortFlag    bool
+)
+
 var updateCmd = &cobra.Command{
-	Use:   "update [<version> | latest]",
+	Use:   "update [<version> | latest | --continue | --abort]",
 	Short: "Push changes to a release branch",
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, err := NewCmdGitRelContext()
 		if err != nil {
 			return err
 		}
 
-		runUpdateCmd(args, ctx)
-		return nil
+		return runUpdateCmd(args, UpdateContinueFlag, UpdateAbortFlag, ctx)
 	},
 }
 
@@ -36,19 +41,36 @@ var updateLatestCmd = &cobra.Command{
 			return err
 		}
 
-		runUpdateCmd([]string{"latest"}, ctx)
-		return nil
+		return runUpdateCmd([]string{"latest"}, false, false, ctx)
 	},
 }
 
 func init() {
+	updateCmd.Flags().BoolVar(&UpdateContinueFlag, "continue", false, "Continue an update after resolving a merge conflict or failed push")
+	updateCmd.Flags().BoolVar(&UpdateAbortFlag, "abort", false, "Abort an update stopped by a merge conflict or failed push")
+	updateCmd.MarkFlagsMutuallyExclusive("continue", "abort")
+
 	updateCmd.AddCommand(updateVersionCmd)
 	updateCmd.AddCommand(updateLatestCmd)
 }
 
-func runUpdateCmd(args []string, ctx interfaces.GitRelContext) {
-	err := git.UpdateVersion(args[0], ctx)
-	if err != nil {
-		ctx.Output().Println(err)
+func runUpdateCmd(args []string, cont bool, abort bool, ctx interfaces.GitRelContext) error {
+	var version semver.Version
+	var err error
+	switch {
+	case cont || abort:
+		if len(args) > 0 {
+			err = usageError("--continue and --abort do not take a version")
+		} else if cont {
+			version, err = git.ContinueUpdate(ctx)
+		} else {
+			err = git.AbortUpdate(ctx)
+		}
+	case len(args) == 0:
+		err = usageError("please specify a version or 'latest' to update")
+	default:
+		version, err = git.UpdateVersion(args[0], ctx)
 	}
+
+	return emitResult("update", version, err, ctx)
 }
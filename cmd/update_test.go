@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"gitrel/git"
 	"gitrel/gitrel_test"
 	"testing"
 )
@@ -16,7 +17,7 @@ func TestRunUpdateCmd_PushesToExistingReleaseBranch(t *testing.T) {
 	ctx.GitContext.CurrentBranch = "main"
 
 	// Act
-	runUpdateCmd([]string{"2.0.0"}, ctx)
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -45,7 +46,7 @@ func TestRunUpdateCmd_PushesToLatestReleaseBranch_WhenLatestVersionIsSpecified(t
 	ctx.GitContext.CurrentBranch = "main"
 
 	// Act
-	runUpdateCmd([]string{"latest"}, ctx)
+	runUpdateCmd([]string{"latest"}, false, false, ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -69,7 +70,7 @@ func TestRunUpdateCmd_PrintsErrorWhenUncommittedChanges(t *testing.T) {
 	ctx.GitContext.HasUncommittedChangesFl = true
 
 	// Act
-	runUpdateCmd([]string{"2.0.0"}, ctx)
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
 
 	// Assert
 	ctx.GitContext.AssertNoSideEffects()
@@ -88,7 +89,7 @@ func TestRunUpdateCmd_WithPrereleaseVersion(t *testing.T) {
 	ctx.GitContext.CurrentBranch = "main"
 
 	// Act
-	runUpdateCmd([]string{"1.0.0-beta.1"}, ctx)
+	runUpdateCmd([]string{"1.0.0-beta.1"}, false, false, ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -116,7 +117,7 @@ func TestRunUpdateCmd_WithBuildMetadata(t *testing.T) {
 	ctx.GitContext.CurrentBranch = "main"
 
 	// Act
-	runUpdateCmd([]string{"1.0.0+build.1"}, ctx)
+	runUpdateCmd([]string{"1.0.0+build.1"}, false, false, ctx)
 
 	// Assert
 	ctx.GitContext.AssertSideEffectsAreExactly(
@@ -144,7 +145,7 @@ func TestRunUpdateCmd_WithInvalidVersion(t *testing.T) {
 	ctx.GitContext.CurrentBranch = "main"
 
 	// Act
-	runUpdateCmd([]string{"invalid-version"}, ctx)
+	runUpdateCmd([]string{"invalid-version"}, false, false, ctx)
 
 	// Assert
 	ctx.GitContext.AssertNoSideEffects()
@@ -152,3 +153,294 @@ func TestRunUpdateCmd_WithInvalidVersion(t *testing.T) {
 		"invalid version format. please use semantic versioning (e.g., 1.0.0, 1.2.3-alpha, 2.0.0+build.1)",
 	)
 }
+
+func TestRunUpdateCmd_TagsMode_PrintsErrorForTagOnlyRelease(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Mode = "tags"
+	ctx.GitContext.Tags = []string{"v1.0.0"}
+
+	// Act
+	runUpdateCmd([]string{"1.0.0"}, false, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"release 1.0.0 has no release branch to update",
+	)
+}
+
+func TestRunUpdateCmd_TagOnRelease_TagsWithNextSequenceNumber(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.TagOnRelease = "v%v+%n"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/2.0.0",
+	}
+	ctx.GitContext.Tags = []string{
+		"v2.0.0+1",
+		"v2.0.0+2",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCheckoutBranch("release/2.0.0"),
+		gitrel_test.EffectMergeBranch("main"),
+		gitrel_test.EffectPushBranch("origin", "release/2.0.0"),
+		gitrel_test.EffectCreateTag("v2.0.0+3", "release/2.0.0"),
+		gitrel_test.EffectPushTag("origin", "v2.0.0+3"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+}
+
+func TestRunUpdateCmd_TagOnRelease_SkipsExistingTag(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.TagOnRelease = "v%v"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/2.0.0",
+	}
+	ctx.GitContext.Tags = []string{"v2.0.0"}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCheckoutBranch("release/2.0.0"),
+		gitrel_test.EffectMergeBranch("main"),
+		gitrel_test.EffectPushBranch("origin", "release/2.0.0"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Checking out release/2.0.0...",
+		"Merging main into release/2.0.0...",
+		"Pushing release/2.0.0 to origin...",
+		"Pushed!",
+		"Tag v2.0.0 already exists, not tagging",
+		"Switched back to branch: main",
+	)
+}
+
+func TestRunUpdateCmd_TagOnRelease_ContinuePushesTheSameTag(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.TagOnRelease = "v%v+%n"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/2.0.0",
+	}
+	ctx.GitContext.Tags = []string{"v2.0.0+1"}
+	ctx.GitContext.CurrentBranch = "main"
+	ctx.GitContext.RejectedPushes = []string{"v2.0.0+2"}
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+
+	ctx.GitContext.Tags = append(ctx.GitContext.Tags, "v2.0.0+2")
+	ctx.GitContext.RejectedPushes = []string{}
+	ctx.GitContext.SideEffects = []gitrel_test.TestGitSideEffect{}
+	ctx.OutputContext.Output = ""
+
+	// Act
+	runUpdateCmd([]string{}, true, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectPushTag("origin", "v2.0.0+2"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Continuing update of release/2.0.0...",
+		"Pushing tag v2.0.0+2 to origin...",
+		"Pushed!",
+		"Switched back to branch: main",
+	)
+}
+
+func TestRunUpdateCmd_MinorLines_PushesToLineBranch(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Line = "minor"
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.3",
+		"release/1.4",
+	}
+	ctx.GitContext.Tags = []string{
+		"v1.3.2",
+	}
+	ctx.GitContext.CurrentBranch = "main"
+
+	// Act
+	runUpdateCmd([]string{"1.3"}, false, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCheckoutBranch("release/1.3"),
+		gitrel_test.EffectMergeBranch("main"),
+		gitrel_test.EffectPushBranch("origin", "release/1.3"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+}
+
+func TestRunUpdateCmd_MergeConflict_SavesStateAndStaysOnReleaseBranch(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.ConflictingBranches = []string{"release/2.0.0"}
+
+	// Act
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectCheckoutBranch("release/2.0.0"),
+		gitrel_test.EffectMergeBranch("main"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Checking out release/2.0.0...",
+		"Merging main into release/2.0.0...",
+		"error merging main into release/2.0.0: CONFLICT merging main into release/2.0.0",
+		"resolve the conflicts, then run 'gitrel update --continue', or 'gitrel update --abort' to stop",
+	)
+	if ctx.GitContext.State["update"] == nil {
+		t.Fatal("expected update state to be saved")
+	}
+}
+
+func TestRunUpdateCmd_Continue_CommitsMergePushesAndSwitchesBack(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.ConflictingBranches = []string{"release/2.0.0"}
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+
+	ctx.GitContext.SideEffects = []gitrel_test.TestGitSideEffect{}
+	ctx.OutputContext.Output = ""
+
+	// Act
+	runUpdateCmd([]string{}, true, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectContinueMerge(),
+		gitrel_test.EffectPushBranch("origin", "release/2.0.0"),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Continuing update of release/2.0.0...",
+		"Pushing release/2.0.0 to origin...",
+		"Pushed!",
+		"Switched back to branch: main",
+	)
+	if ctx.GitContext.State["update"] != nil {
+		t.Fatal("expected update state to be cleared")
+	}
+}
+
+func TestRunUpdateCmd_Abort_DeletesCreatedBranchAndSwitchesBack(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"remotes/origin/release/2.0.0",
+	}
+	ctx.GitContext.ConflictingBranches = []string{"release/2.0.0"}
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+
+	ctx.GitContext.SideEffects = []gitrel_test.TestGitSideEffect{}
+	ctx.OutputContext.Output = ""
+
+	// Act
+	runUpdateCmd([]string{}, false, true, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectAbortMerge(),
+		gitrel_test.EffectCheckoutBranch("main"),
+		gitrel_test.EffectDeleteBranch("release/2.0.0"),
+	)
+	ctx.OutputContext.AssertOutputLines(
+		"Deleted branch: release/2.0.0",
+		"Aborted update of release/2.0.0",
+		"Switched back to branch: main",
+	)
+	if ctx.GitContext.State["update"] != nil {
+		t.Fatal("expected update state to be cleared")
+	}
+}
+
+func TestRunUpdateCmd_Abort_KeepsExistingBranch(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.ConflictingBranches = []string{"release/2.0.0"}
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+
+	ctx.GitContext.SideEffects = []gitrel_test.TestGitSideEffect{}
+	ctx.OutputContext.Output = ""
+
+	// Act
+	runUpdateCmd([]string{}, false, true, ctx)
+
+	// Assert
+	ctx.GitContext.AssertSideEffectsAreExactly(
+		gitrel_test.EffectAbortMerge(),
+		gitrel_test.EffectCheckoutBranch("main"),
+	)
+}
+
+func TestRunUpdateCmd_PrintsErrorWhenUpdateInProgress(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.ConflictingBranches = []string{"release/2.0.0"}
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+
+	ctx.GitContext.SideEffects = []gitrel_test.TestGitSideEffect{}
+	ctx.OutputContext.Output = ""
+
+	// Act
+	runUpdateCmd([]string{"1.1.1"}, false, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"an update is already in progress. run 'gitrel update --continue' or 'gitrel update --abort'",
+	)
+}
+
+func TestRunUpdateCmd_Continue_PrintsErrorWhenNoUpdateInProgress(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+
+	// Act
+	runUpdateCmd([]string{}, true, false, ctx)
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines("no update in progress")
+}
+
+func TestRunUpdateCmd_ResultsHoldTheUpdatedVersion(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.ConflictingBranches = []string{"release/2.0.0"}
+
+	// Act
+	runUpdateCmd([]string{"2.0.0"}, false, false, ctx)
+	runUpdateCmd([]string{}, true, false, ctx)
+
+	// Assert
+	if len(ctx.OutputContext.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(ctx.OutputContext.Results))
+	}
+
+	for _, result := range ctx.OutputContext.Results {
+		if version := result.(*git.OperationResult).Version; version != "2.0.0" {
+			t.Errorf("expected version 2.0.0 in the result, got %q", version)
+		}
+	}
+}
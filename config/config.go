@@ -13,6 +13,20 @@ var (
 	RemoteConfig string
 	LocalBranchNameConfig string
 	RemoteBranchNameConfig string
+	ModeConfig string
+	LineConfig string
+	ComponentConfig string
+	TagNameConfig string
+	TagOnReleaseConfig string
+	TagMessageConfig string
+	BackendConfig string
+	BumpTypesConfig string
+	ChangelogFileConfig string
+	ChangelogPatternConfig string
+	IssuePatternConfig string
+	NotesTemplateConfig string
+	VersionFilesConfig string
+	VersionCommitMessageConfig string
 )
 
 func InitConfig() {
@@ -22,6 +36,20 @@ func InitConfig() {
 	RemoteConfig = viper.GetString("remote")
 	LocalBranchNameConfig = viper.GetString("local-branch-name")
 	RemoteBranchNameConfig = viper.GetString("remote-branch-name")
+	ModeConfig = viper.GetString("mode")
+	LineConfig = viper.GetString("line")
+	ComponentConfig = viper.GetString("component")
+	TagNameConfig = viper.GetString("tagName")
+	TagOnReleaseConfig = viper.GetString("tagOnRelease")
+	TagMessageConfig = viper.GetString("tagMessage")
+	BackendConfig = viper.GetString("backend")
+	BumpTypesConfig = viper.GetString("bumpTypes")
+	ChangelogFileConfig = viper.GetString("changelogFile")
+	ChangelogPatternConfig = viper.GetString("changelogPattern")
+	IssuePatternConfig = viper.GetString("issuePattern")
+	NotesTemplateConfig = viper.GetString("notesTemplate")
+	VersionFilesConfig = viper.GetString("versionFiles")
+	VersionCommitMessageConfig = viper.GetString("versionCommitMessage")
 }
 
 func loadConfig() {
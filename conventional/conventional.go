@@ -0,0 +1,121 @@
+package conventional
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Commit is a commit message read as a Conventional Commit, e.g.
+// feat(api)!: remove the v1 endpoints
+type Commit struct {
+	Type        string
+	Scope       string
+	Breaking    bool
+	Description string
+}
+
+var headerPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9-]*)(?:\(([^()]*)\))?(!)?: *(.+)$`)
+
+var breakingFooterPattern = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+
+// Parse reads a commit subject and body, returning false if the subject isn't
+// a Conventional Commit header. A ! after the type or scope, or a BREAKING
+// CHANGE footer in the body, marks a breaking change.
+func Parse(subject string, body string) (Commit, bool) {
+	match := headerPattern.FindStringSubmatch(strings.TrimSpace(subject))
+	if match == nil {
+		return Commit{}, false
+	}
+
+	return Commit{
+		Type:        strings.ToLower(match[1]),
+		Scope:       match[2],
+		Breaking:    match[3] != "" || breakingFooterPattern.MatchString(body),
+		Description: match[4],
+	}, true
+}
+
+// Version parts a commit can bump, from least to most significant. BumpNone
+// means the commit doesn't call for a release.
+const (
+	BumpNone  = "none"
+	BumpPatch = "patch"
+	BumpMinor = "minor"
+	BumpMajor = "major"
+)
+
+var bumpOrder = []string{BumpNone, BumpPatch, BumpMinor, BumpMajor}
+
+// DefaultBumpRules maps commit types to the part they bump when no rules are
+// configured
+func DefaultBumpRules() map[string]string {
+	return map[string]string{
+		"feat": BumpMinor,
+		"fix":  BumpPatch,
+		"perf": BumpPatch,
+	}
+}
+
+// ParseBumpRules reads rules written as type=part pairs separated by commas,
+// e.g. feat=minor,fix=patch,docs=none, on top of the default rules
+func ParseBumpRules(rules string) (map[string]string, error) {
+	parsed := DefaultBumpRules()
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		commitType, part, ok := strings.Cut(rule, "=")
+		commitType = strings.ToLower(strings.TrimSpace(commitType))
+		part = strings.TrimSpace(part)
+		if !ok || commitType == "" || !slices.Contains(bumpOrder, part) {
+			return nil, fmt.Errorf("invalid bump rule '%s'. please use type=part, where part is one of: major, minor, patch, none", rule)
+		}
+
+		parsed[commitType] = part
+	}
+
+	return parsed, nil
+}
+
+// FormatBumpRules writes rules in the form ParseBumpRules reads, sorted by type
+func FormatBumpRules(rules map[string]string) string {
+	types := make([]string, 0, len(rules))
+	for commitType := range rules {
+		types = append(types, commitType)
+	}
+
+	sort.Strings(types)
+	for i, commitType := range types {
+		types[i] = commitType + "=" + rules[commitType]
+	}
+
+	return strings.Join(types, ",")
+}
+
+// Bump returns the part a commit bumps under the rules. Breaking changes bump
+// the major version whatever their type.
+func Bump(commit Commit, rules map[string]string) string {
+	if commit.Breaking {
+		return BumpMajor
+	}
+
+	if part, ok := rules[commit.Type]; ok {
+		return part
+	}
+
+	return BumpNone
+}
+
+// HigherBump returns whichever of two parts is more significant
+func HigherBump(a string, b string) string {
+	if slices.Index(bumpOrder, b) > slices.Index(bumpOrder, a) {
+		return b
+	}
+
+	return a
+}
@@ -0,0 +1,111 @@
+package conventional
+
+import "testing"
+
+func TestParse_ParsesConventionalCommits(t *testing.T) {
+	tests := []struct {
+		subject  string
+		body     string
+		expected Commit
+	}{
+		{"feat: add describe", "", Commit{Type: "feat", Description: "add describe"}},
+		{"fix(api): handle empty tags", "", Commit{Type: "fix", Scope: "api", Description: "handle empty tags"}},
+		{"feat!: drop the v1 config", "", Commit{Type: "feat", Breaking: true, Description: "drop the v1 config"}},
+		{"refactor(git)!: rename contexts", "", Commit{Type: "refactor", Scope: "git", Breaking: true, Description: "rename contexts"}},
+		{"Fix: capitalised type", "", Commit{Type: "fix", Description: "capitalised type"}},
+		{"feat: new flag", "Details\n\nBREAKING CHANGE: --old is gone", Commit{Type: "feat", Breaking: true, Description: "new flag"}},
+		{"fix: new flag", "BREAKING-CHANGE: output changed", Commit{Type: "fix", Breaking: true, Description: "new flag"}},
+		{"docs: mention BREAKING CHANGE: in the guide", "", Commit{Type: "docs", Description: "mention BREAKING CHANGE: in the guide"}},
+	}
+
+	for _, test := range tests {
+		// Act
+		actual, ok := Parse(test.subject, test.body)
+
+		// Assert
+		if !ok {
+			t.Fatalf("Parse(%q): expected a conventional commit", test.subject)
+		}
+
+		if actual != test.expected {
+			t.Fatalf("Parse(%q): expected %+v, got %+v", test.subject, test.expected, actual)
+		}
+	}
+}
+
+func TestParse_RejectsOtherSubjects(t *testing.T) {
+	subjects := []string{
+		"",
+		"Add describe",
+		"Merge branch 'main' into release/1.0.0",
+		"feat add describe",
+		"feat(: unbalanced",
+		": no type",
+	}
+
+	for _, subject := range subjects {
+		// Act
+		_, ok := Parse(subject, "")
+
+		// Assert
+		if ok {
+			t.Fatalf("Parse(%q): expected not to be a conventional commit", subject)
+		}
+	}
+}
+
+func TestParseBumpRules_OverridesDefaults(t *testing.T) {
+	// Act
+	rules, err := ParseBumpRules("fix=minor, docs=patch,perf=none")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "docs=patch,feat=minor,fix=minor,perf=none"
+	if actual := FormatBumpRules(rules); actual != expected {
+		t.Fatalf("Expected %s, got %s", expected, actual)
+	}
+}
+
+func TestParseBumpRules_RejectsInvalidRules(t *testing.T) {
+	for _, rules := range []string{"feat", "feat=huge", "=minor"} {
+		// Act
+		_, err := ParseBumpRules(rules)
+
+		// Assert
+		if err == nil {
+			t.Fatalf("ParseBumpRules(%q): expected an error", rules)
+		}
+	}
+}
+
+func TestBump_UsesRulesAndBreakingChanges(t *testing.T) {
+	rules := DefaultBumpRules()
+	tests := []struct {
+		commit   Commit
+		expected string
+	}{
+		{Commit{Type: "feat"}, BumpMinor},
+		{Commit{Type: "fix"}, BumpPatch},
+		{Commit{Type: "chore"}, BumpNone},
+		{Commit{Type: "chore", Breaking: true}, BumpMajor},
+	}
+
+	for _, test := range tests {
+		// Act
+		actual := Bump(test.commit, rules)
+
+		// Assert
+		if actual != test.expected {
+			t.Fatalf("Bump(%+v): expected %s, got %s", test.commit, test.expected, actual)
+		}
+	}
+}
+
+func TestHigherBump(t *testing.T) {
+	if HigherBump(BumpPatch, BumpMinor) != BumpMinor || HigherBump(BumpMajor, BumpMinor) != BumpMajor || HigherBump(BumpNone, BumpPatch) != BumpPatch {
+		t.Fatal("Expected the more significant part")
+	}
+}
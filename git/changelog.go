@@ -0,0 +1,355 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"gitrel/conventional"
+	"gitrel/interfaces"
+	"gitrel/semver"
+	"gitrel/utils"
+	"io/fs"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// changelogSections maps Conventional Commit types to the Keep a Changelog
+// section they are listed under. Types that don't change what users see are
+// left out of the changelog.
+var changelogSections = map[string]string{
+	"feat":      "Added",
+	"perf":      "Changed",
+	"refactor":  "Changed",
+	"deprecate": "Deprecated",
+	"revert":    "Removed",
+	"fix":       "Fixed",
+	"security":  "Security",
+	"build":     "",
+	"chore":     "",
+	"ci":        "",
+	"docs":      "",
+	"style":     "",
+	"test":      "",
+}
+
+// changelogSectionOrder is the order of the Keep a Changelog sections. Other
+// groups follow in the order they first appear, with Other last.
+var changelogSectionOrder = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security"}
+
+const otherSection = "Other"
+
+const changelogHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+
+// GetChangelog groups the commits between two releases, given as version
+// prefixes, latest or current. Without to, the latest release is used, and
+// without from, the release before to.
+func GetChangelog(from string, to string, ctx interfaces.GitRelContext) (*Changelog, error) {
+	releases, err := getVersionReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	toRelease, err := resolveRelease(utils.CoalesceStr(to, "latest"), releases, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var fromRelease *ReleaseInfo
+	if from != "" {
+		fromRelease, err = resolveRelease(from, releases, ctx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		fromRelease = previousRelease(toRelease, releases)
+	}
+
+	since := ""
+	if fromRelease != nil {
+		since = fromRelease.GetReleasePoint().BranchName
+	}
+
+	commits, err := ctx.Git().ListCommits(since, toRelease.GetReleasePoint().BranchName)
+	if err != nil {
+		return nil, fmt.Errorf("error listing commits for %s: %w", toRelease.Version, err)
+	}
+
+	changelog, err := newChangelog(commits, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := ctx.Git().RefDate(toRelease.GetReleasePoint().RefName())
+	if err != nil {
+		return nil, fmt.Errorf("error reading the date of %s: %w", toRelease.Version, err)
+	}
+
+	changelog.Date = date.Format(time.DateOnly)
+	changelog.Version = toRelease.Version.String()
+	if fromRelease != nil {
+		changelog.Previous = fromRelease.Version.String()
+	}
+
+	return changelog, nil
+}
+
+// resolveRelease finds the release for a version prefix the way checkout
+// does, or the release HEAD is on for current
+func resolveRelease(versionish string, releases []*ReleaseInfo, ctx interfaces.GitRelContext) (*ReleaseInfo, error) {
+	if versionish != "current" {
+		return findLatestRelease(versionish, releases)
+	}
+
+	current, ok := getCurrentVersionFromBranch(ctx)
+	if ok {
+		// Earlier patches of a release line are on the line too, but the
+		// branch is the latest
+		for _, release := range releases {
+			if isSameRelease(release, current, ctx) && release.HasBranch() {
+				return release, nil
+			}
+		}
+	}
+
+	return nil, newError(ErrNotFound, "not on a release branch")
+}
+
+// previousRelease returns the release before another in the ordered
+// releases, or nil if it is the first
+func previousRelease(release *ReleaseInfo, releases []*ReleaseInfo) *ReleaseInfo {
+	i := slices.Index(releases, release)
+	if i <= 0 {
+		return nil
+	}
+
+	return releases[i-1]
+}
+
+// newChangelog groups commits, newest first, by Conventional Commit type, or
+// by the first group of the changelog pattern if one is set. Merge commits are
+// left out.
+func newChangelog(commits []interfaces.Commit, ctx interfaces.GitRelContext) (*Changelog, error) {
+	changelog := &Changelog{
+		Groups:  []ChangelogGroup{},
+		commits: commits,
+	}
+
+	var pattern *regexp.Regexp
+	if source := ctx.Command().GetOptChangelogPattern(); source != "" {
+		var err error
+		pattern, err = regexp.Compile(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid changelog pattern: %w", err)
+		}
+	}
+
+	if source := ctx.Command().GetOptIssuePattern(); source != "" {
+		var err error
+		changelog.issuePattern, err = regexp.Compile(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue pattern: %w", err)
+		}
+	}
+
+	for _, commit := range commits {
+		if strings.HasPrefix(commit.Subject, "Merge ") {
+			continue
+		}
+
+		title, entry, ok := changelogEntry(commit, pattern, changelog.issuePattern)
+		if ok {
+			changelog.add(title, entry)
+		}
+	}
+
+	slices.SortStableFunc(changelog.Groups, func(a, b ChangelogGroup) int {
+		return sectionRank(a.Title) - sectionRank(b.Title)
+	})
+
+	return changelog, nil
+}
+
+// changelogEntry returns the group a commit is listed under and its entry, or
+// false if it is left out of the changelog
+func changelogEntry(commit interfaces.Commit, pattern *regexp.Regexp, issuePattern *regexp.Regexp) (string, ChangelogEntry, bool) {
+	entry := ChangelogEntry{
+		SHA:         commit.SHA,
+		Author:      commit.Author,
+		Description: commit.Subject,
+		Issues:      issueKeys(commit.Subject+"\n"+commit.Body, issuePattern),
+	}
+
+	if pattern != nil {
+		match := pattern.FindStringSubmatchIndex(commit.Subject)
+		if match == nil || len(match) < 4 || match[2] < 0 {
+			return otherSection, entry, true
+		}
+
+		entry.Description = strings.TrimSpace(commit.Subject[:match[0]] + commit.Subject[match[1]:])
+		return commit.Subject[match[2]:match[3]], entry, true
+	}
+
+	parsed, ok := conventional.Parse(commit.Subject, commit.Body)
+	if !ok {
+		return otherSection, entry, true
+	}
+
+	entry.Scope = parsed.Scope
+	entry.Description = parsed.Description
+	entry.Breaking = parsed.Breaking
+
+	title, known := changelogSections[parsed.Type]
+	switch {
+	case !known:
+		title = otherSection
+	case title == "" && !parsed.Breaking:
+		return "", entry, false
+	case title == "":
+		title = "Changed"
+	}
+
+	return title, entry, true
+}
+
+func sectionRank(title string) int {
+	if i := slices.Index(changelogSectionOrder, title); i >= 0 {
+		return i
+	}
+
+	if title == otherSection {
+		return len(changelogSectionOrder) + 1
+	}
+
+	return len(changelogSectionOrder)
+}
+
+// WriteChangelog adds the changelog as a section of a Keep a Changelog file,
+// replacing the section for the same version if there is one. A new file is
+// started with the usual header.
+func WriteChangelog(path string, changelog *Changelog) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	contents := insertChangelogSection(string(existing), changelog.Version, changelog.Markdown())
+	return os.WriteFile(path, []byte(contents), 0o644)
+}
+
+// insertChangelogSection puts a version's section in a changelog in place of
+// the existing section for that version, or else after the Unreleased section
+// and before any older release
+func insertChangelogSection(existing string, version string, section string) string {
+	if strings.TrimSpace(existing) == "" {
+		return changelogHeader + "\n" + section
+	}
+
+	lines := strings.SplitAfter(existing, "\n")
+	headings := []int{}
+	for i, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			headings = append(headings, i)
+		}
+	}
+
+	for k, i := range headings {
+		heading := strings.TrimSpace(strings.TrimPrefix(lines[i], "## "))
+		if !strings.HasPrefix(heading, "["+version+"]") && heading != version && !strings.HasPrefix(heading, version+" ") {
+			continue
+		}
+
+		if k+1 == len(headings) {
+			return strings.Join(lines[:i], "") + section
+		}
+
+		return strings.Join(lines[:i], "") + section + "\n" + strings.Join(lines[headings[k+1]:], "")
+	}
+
+	for _, i := range headings {
+		heading := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(lines[i], "## ")))
+		if !strings.HasPrefix(heading, "[unreleased]") && heading != "unreleased" {
+			return strings.Join(lines[:i], "") + section + "\n" + strings.Join(lines[i:], "")
+		}
+	}
+
+	return strings.TrimRight(existing, "\n") + "\n\n" + section
+}
+
+// writeChangelogHook adds a release to the changelog file, if one is
+// configured, once it has been created or updated. The file is read from the
+// release branch, with its path taken from the root of the repository, and
+// committed back onto the branch, which is pushed again.
+func writeChangelogHook(version semver.Version, ctx interfaces.GitRelContext) error {
+	path := ctx.Command().GetOptChangelogFile()
+	if path == "" {
+		return nil
+	}
+
+	if isDryRun(ctx) {
+		ctx.Output().Printf("Would update %s with %s\n", path, version)
+		return nil
+	}
+
+	err := commitChangelog(path, version, ctx)
+	if err != nil {
+		return fmt.Errorf("error updating %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func commitChangelog(path string, version semver.Version, ctx interfaces.GitRelContext) error {
+	changelog, err := GetChangelog("", version.String(), ctx)
+	if err != nil {
+		return err
+	}
+
+	releases, err := getReleases(ctx)
+	if err != nil {
+		return err
+	}
+
+	var release *ReleaseInfo
+	for _, r := range releases {
+		if isSameRelease(r, version, ctx) {
+			release = r
+		}
+	}
+
+	if release == nil {
+		return newError(ErrNotFound, "release %s not found", version)
+	}
+
+	branch, err := getOrCreateLocalBranch(release, ctx)
+	if err != nil {
+		return err
+	}
+
+	existing, err := ctx.Git().ReadFile(branch.BranchName, path)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	contents := insertChangelogSection(string(existing), changelog.Version, changelog.Markdown())
+	if contents == string(existing) {
+		ctx.Output().Printf("%s already has %s\n", path, changelog.Version)
+		return nil
+	}
+
+	message := fmt.Sprintf("docs: update %s for %s", path, changelog.Version)
+	err = ctx.Git().CommitFiles(branch.BranchName, map[string][]byte{path: []byte(contents)}, message)
+	if err != nil {
+		return err
+	}
+
+	ctx.Output().Printf("Updated %s with %s on %s\n", path, changelog.Version, branch.BranchName)
+	return pushReleaseBranch(branch.BranchName, version, ctx)
+}
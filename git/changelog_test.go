@@ -0,0 +1,69 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSection = "## [1.2.0] - 2026-10-18\n\n### Added\n\n- describe (abc1234)\n"
+
+func TestInsertChangelogSection_StartsNewChangelog(t *testing.T) {
+	// Act
+	actual := insertChangelogSection("", "1.2.0", testSection)
+
+	// Assert
+	if actual != changelogHeader+"\n"+testSection {
+		t.Fatalf("unexpected changelog:\n%s", actual)
+	}
+}
+
+func TestInsertChangelogSection_InsertsAfterUnreleased(t *testing.T) {
+	// Arrange
+	existing := "# Changelog\n\n## [Unreleased]\n\n- Pending\n\n## [1.1.0] - 2026-01-01\n\n- Older\n"
+
+	// Act
+	actual := insertChangelogSection(existing, "1.2.0", testSection)
+
+	// Assert
+	expected := "# Changelog\n\n## [Unreleased]\n\n- Pending\n\n" + testSection + "\n## [1.1.0] - 2026-01-01\n\n- Older\n"
+	if actual != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
+
+func TestInsertChangelogSection_ReplacesExistingSection(t *testing.T) {
+	// Arrange
+	existing := "# Changelog\n\n## [1.2.0] - 2026-10-01\n\n- Stale\n\n## [1.1.0] - 2026-01-01\n\n- Older\n"
+
+	// Act
+	actual := insertChangelogSection(existing, "1.2.0", testSection)
+
+	// Assert
+	expected := "# Changelog\n\n" + testSection + "\n## [1.1.0] - 2026-01-01\n\n- Older\n"
+	if actual != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
+
+func TestInsertChangelogSection_AppendsWithoutReleases(t *testing.T) {
+	// Act
+	actual := insertChangelogSection("# Changelog\n", "1.2.0", testSection)
+
+	// Assert
+	if actual != "# Changelog\n\n"+testSection {
+		t.Fatalf("unexpected changelog:\n%s", actual)
+	}
+}
+
+func TestInsertChangelogSection_DoesNotMatchLongerVersion(t *testing.T) {
+	// Arrange
+	existing := "# Changelog\n\n## [1.2.0-rc.1] - 2026-10-01\n\n- Candidate\n"
+
+	// Act
+	actual := insertChangelogSection(existing, "1.2.0", testSection)
+
+	// Assert
+	if !strings.Contains(actual, "- Candidate") || !strings.HasPrefix(actual, "# Changelog\n\n"+testSection) {
+		t.Fatalf("unexpected changelog:\n%s", actual)
+	}
+}
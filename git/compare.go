@@ -0,0 +1,77 @@
+package git
+
+import (
+	"fmt"
+	"gitrel/interfaces"
+)
+
+// GetLog lists the commits in one release that aren't in another, each given
+// as a version prefix, latest or current, newest first
+func GetLog(from string, to string, ctx interfaces.GitRelContext) (*Log, error) {
+	fromRelease, toRelease, err := resolveReleasePair(from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := ctx.Git().ListCommits(fromRelease.GetReleasePoint().BranchName, toRelease.GetReleasePoint().BranchName)
+	if err != nil {
+		return nil, fmt.Errorf("error listing commits from %s to %s: %w", fromRelease.Version, toRelease.Version, err)
+	}
+
+	return newLog(fromRelease, toRelease, commits), nil
+}
+
+// GetDiff shows the changes from one release to another, each given as a
+// version prefix, latest or current, as a patch or, with stat, as the lines
+// changed in each file
+func GetDiff(from string, to string, stat bool, ctx interfaces.GitRelContext) (*Diff, error) {
+	fromRelease, toRelease, err := resolveReleasePair(from, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fromRef := fromRelease.GetReleasePoint().BranchName
+	toRef := toRelease.GetReleasePoint().BranchName
+	diff := &Diff{
+		From: fromRelease.Version.String(),
+		To:   toRelease.Version.String(),
+	}
+
+	if stat {
+		files, err := ctx.Git().DiffStat(fromRef, toRef)
+		if err != nil {
+			return nil, fmt.Errorf("error comparing %s to %s: %w", diff.From, diff.To, err)
+		}
+
+		diff.Files = newDiffFiles(files)
+		return diff, nil
+	}
+
+	diff.Patch, err = ctx.Git().Diff(fromRef, toRef)
+	if err != nil {
+		return nil, fmt.Errorf("error comparing %s to %s: %w", diff.From, diff.To, err)
+	}
+
+	return diff, nil
+}
+
+// resolveReleasePair finds the releases for two version prefixes, latest or
+// current, as checkout does
+func resolveReleasePair(from string, to string, ctx interfaces.GitRelContext) (*ReleaseInfo, *ReleaseInfo, error) {
+	releases, err := getVersionReleases(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fromRelease, err := resolveRelease(from, releases, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toRelease, err := resolveRelease(to, releases, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return fromRelease, toRelease, nil
+}
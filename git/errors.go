@@ -0,0 +1,40 @@
+package git
+
+import (
+	"fmt"
+	"gitrel/interfaces"
+	"gitrel/utils"
+	"strings"
+)
+
+// Kinds of failure returned by operations in this package. Check for them
+// with errors.Is.
+var (
+	ErrNotFound        = interfaces.ErrNotFound
+	ErrAlreadyExists   = interfaces.ErrAlreadyExists
+	ErrDirtyWorktree   = interfaces.ErrDirtyWorktree
+	ErrMergeConflict   = interfaces.ErrMergeConflict
+	ErrPushRejected    = interfaces.ErrPushRejected
+	ErrAmbiguousRemote = interfaces.ErrAmbiguousRemote
+)
+
+// newError formats an error message that errors.Is matches to kind
+func newError(kind error, format string, args ...any) error {
+	return utils.WithKind(kind, fmt.Errorf(format, args...))
+}
+
+// commandError adds the output of a failed git command to its error, and
+// recognises conflicts and rejected pushes in that output
+func commandError(err error, output string) error {
+	output = strings.TrimSpace(output)
+	err = fmt.Errorf("%w: %s", err, output)
+
+	switch {
+	case strings.Contains(output, "CONFLICT") || strings.Contains(output, "could not apply"):
+		return utils.WithKind(ErrMergeConflict, err)
+	case strings.Contains(output, "[rejected]") || strings.Contains(output, "[remote rejected]"):
+		return utils.WithKind(ErrPushRejected, err)
+	}
+
+	return err
+}
@@ -1,10 +1,13 @@
 package git
 
 import (
+	"errors"
 	"fmt"
+	"gitrel/conventional"
 	"gitrel/interfaces"
 	"gitrel/semver"
-	"sort"
+	"gitrel/utils"
+	"slices"
 	"strings"
 )
 
@@ -13,70 +16,885 @@ func ListReleases(ctx interfaces.GitRelContext) ([]*ReleaseInfo, error) {
 	return getReleases(ctx)
 }
 
-// Function to create a new release branch
-func CreateReleaseBranch(version string, ctx interfaces.GitRelContext) error {
-	if !semver.ValidateSemver(version) {
-		return fmt.Errorf("invalid version format. please use semantic versioning (e.g., 1.0.0, 1.2.3-alpha, 2.0.0+build.1)")
+// Function to create a new release, as a branch, a tag or both depending on
+// the release mode
+func CreateRelease(version semver.Version, from string, ctx interfaces.GitRelContext) error {
+	err := inTransaction("new "+version.String(), ctx, func(ctx interfaces.GitRelContext) error {
+		return createRelease(version, from, ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeChangelogHook(version, ctx)
+}
+
+func createRelease(version semver.Version, from string, ctx interfaces.GitRelContext) error {
+	mode := ctx.Command().GetOptMode()
+	if !usesBranches(mode) {
+		return CreateReleaseTag(version, from, ctx)
+	}
+
+	if usesLines(ctx) {
+		return createLineRelease(version, from, ctx)
+	}
+
+	if usesTags(mode) {
+		// Check up front so we don't push a branch and then fail on the tag
+		_, err := checkReleaseTagAvailable(version, ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := CreateReleaseBranch(version, from, ctx)
+	if err != nil || !usesTags(mode) {
+		return err
+	}
+
+	localBranchName, err := replaceInBranchPattern(ctx.Command().GetOptLocalBranchName(), version, ctx)
+	if err != nil {
+		return err
+	}
+
+	return CreateReleaseTag(version, localBranchName, ctx)
+}
+
+// createLineRelease tags a new version on its release line branch, creating
+// the line branch first if this is the first release on the line
+func createLineRelease(version semver.Version, from string, ctx interfaces.GitRelContext) error {
+	_, err := checkReleaseTagAvailable(version, ctx)
+	if err != nil {
+		return err
+	}
+
+	releases, err := getReleases(ctx)
+	if err != nil {
+		return err
+	}
+
+	var lineRelease *ReleaseInfo
+	for _, r := range releases {
+		if isSameRelease(r, version, ctx) {
+			lineRelease = r
+		}
+	}
+
+	ref := from
+	if lineRelease == nil {
+		err = CreateReleaseBranch(version, from, ctx)
+		if err != nil {
+			return err
+		}
+
+		ref, err = replaceInBranchPattern(ctx.Command().GetOptLocalBranchName(), version, ctx)
+		if err != nil {
+			return err
+		}
+	} else if len(ctx.Command().GetOptVersionFiles()) > 0 {
+		if from != "" {
+			return fmt.Errorf("cannot set the version in version files when tagging %s at %s, as it is not the tip of the release line", version, from)
+		}
+
+		ref, err = commitLineVersionFiles(lineRelease, version, ctx)
+		if err != nil {
+			return err
+		}
+	} else if ref == "" {
+		lineBranch := lineRelease.GetFirstLocalBranch()
+		if lineBranch == nil {
+			lineBranch = lineRelease.GetFirstRemoteBranch()
+		}
+
+		ref = lineBranch.BranchName
+	}
+
+	return CreateReleaseTag(version, ref, ctx)
+}
+
+// commitLineVersionFiles commits the version files for a new version onto
+// the branch of its release line and pushes it, returning the branch to tag
+func commitLineVersionFiles(lineRelease *ReleaseInfo, version semver.Version, ctx interfaces.GitRelContext) (string, error) {
+	lineBranch, err := getOrCreateLocalBranch(lineRelease, ctx)
+	if err != nil {
+		return "", err
+	}
+
+	committed, err := commitVersionFiles(lineBranch.BranchName, lineBranch.BranchName, version, ctx)
+	if err != nil || !committed {
+		return lineBranch.BranchName, err
+	}
+
+	return lineBranch.BranchName, pushReleaseBranch(lineBranch.BranchName, version, ctx)
+}
+
+// Function to create and push an annotated release tag, either at HEAD or, if
+// from is given, at that commit-ish
+func CreateReleaseTag(version semver.Version, from string, ctx interfaces.GitRelContext) error {
+	tagName, err := checkReleaseTagAvailable(version, ctx)
+	if err != nil {
+		return err
+	}
+
+	err = ctx.Git().CreateTag(tagName, from, "Release "+version.String())
+	if err != nil {
+		return fmt.Errorf("error creating tag: %w", err)
+	}
+
+	if from != "" {
+		reportChange(ctx, "Created new release tag: %s (from %s)\n", "Would create release tag: %s (from %s)\n", tagName, from)
+	} else {
+		reportChange(ctx, "Created new release tag: %s\n", "Would create release tag: %s\n", tagName)
+	}
+
+	reportChange(ctx, "Pushing tag %v to %v...\n", "Would push tag %v to %v\n", tagName, ctx.Command().GetOptRemote())
+	err = ctx.Git().PushTag(ctx.Command().GetOptRemote(), tagName)
+	if err != nil {
+		return err
+	}
+
+	reportPushed(ctx)
+	return nil
+}
+
+// Function to create a new release branch, either from HEAD or, if from is
+// given, at that commit-ish without touching the working tree. Any version
+// files are set to the new version in a commit on the branch.
+func CreateReleaseBranch(version semver.Version, from string, ctx interfaces.GitRelContext) error {
+	return inTransaction("new "+version.String(), ctx, func(ctx interfaces.GitRelContext) error {
+		return createReleaseBranch(version, from, ctx)
+	})
+}
+
+func createReleaseBranch(version semver.Version, from string, ctx interfaces.GitRelContext) error {
+	localBranchName, err := checkReleaseBranchAvailable(version, ctx)
+	if err != nil {
+		return err
+	}
+
+	// Version files are committed onto the branch without checking it out, so
+	// the branch is created where it is
+	if from != "" || len(ctx.Command().GetOptVersionFiles()) > 0 {
+		at := utils.CoalesceStr(from, "HEAD")
+		err = ctx.Git().CreateBranchAt(localBranchName, at)
+		if err != nil {
+			return fmt.Errorf("error creating branch at %s: %w", at, err)
+		}
+
+		if from != "" {
+			reportChange(ctx, "Created new release branch: %s (from %s)\n", "Would create release branch: %s (from %s)\n", localBranchName, from)
+		} else {
+			reportChange(ctx, "Created new release branch: %s\n", "Would create release branch: %s\n", localBranchName)
+		}
+
+		_, err = commitVersionFiles(localBranchName, at, version, ctx)
+		if err != nil {
+			return err
+		}
+
+		return pushReleaseBranch(localBranchName, version, ctx)
+	}
+
+	err = ctx.Git().SwitchToNewBranch(localBranchName)
+	if err != nil {
+		return err
+	}
+
+	reportChange(ctx, "Created new release branch: %s\n", "Would create release branch: %s\n", localBranchName)
+
+	err = pushReleaseBranch(localBranchName, version, ctx)
+	if err != nil {
+		return err
+	}
+
+	err = ctx.Git().SwitchBack()
+	if err != nil {
+		return err
+	}
+
+	curBranch, err := ctx.Git().GetCurrentBranch()
+	if err != nil {
+		return err
+	}
+
+	reportChange(ctx, "Switched back to branch: %s\n", "Would switch back to branch: %s\n", curBranch)
+
+	return nil
+}
+
+// Function to promote the latest prerelease of a version to a final release,
+// returning the final version
+func PromoteVersion(versionish string, ctx interfaces.GitRelContext) (semver.Version, error) {
+	version, err := semver.Parse(versionish)
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	releases, err := getReleases(ctx)
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	// Releases are ordered, so the last matching prerelease is the latest
+	var prerelease *ReleaseInfo
+	for _, r := range releases {
+		if !r.Version.IsPrerelease() || r.Version.Release() != version.Release() {
+			continue
+		}
+
+		if !version.IsPrerelease() || r.Version.Compare(version) == 0 {
+			prerelease = r
+		}
+	}
+
+	if prerelease == nil {
+		return semver.Version{}, newError(ErrNotFound, "no prerelease found for version: %s", versionish)
+	}
+
+	source := prerelease.GetFirstLocalBranch()
+	if source == nil {
+		source = prerelease.GetFirstRemoteBranch()
 	}
+	if source == nil {
+		source = prerelease.GetFirstTag()
+	}
+
+	ctx.Output().Printf("Promoting %v to %v...\n", prerelease.Version, version.Release())
+
+	return version.Release(), CreateRelease(version.Release(), source.BranchName, ctx)
+}
+
+// checkReleaseTagAvailable returns the tag name for a new release, or an error
+// if the tag already exists
+func checkReleaseTagAvailable(version semver.Version, ctx interfaces.GitRelContext) (string, error) {
+	tagName, err := replaceInTagPattern(ctx.Command().GetOptTagName(), version, ctx)
+	if err != nil {
+		return "", err
+	}
+
+	tags, err := ctx.Git().ListTags()
+	if err != nil {
+		return "", fmt.Errorf("error listing tags: %w", err)
+	}
+
+	remoteExists, err := existsOnRemote("refs/tags/"+tagName, ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if slices.Contains(tags, tagName) || remoteExists {
+		return "", newError(ErrAlreadyExists, "tag %s already exists", tagName)
+	}
+
+	return tagName, nil
+}
+
+// checkReleaseBranchAvailable returns the local branch name for a new release,
+// or an error if a local or remote branch already exists for it
+func checkReleaseBranchAvailable(version semver.Version, ctx interfaces.GitRelContext) (string, error) {
+	localBranchName, err := replaceInBranchPattern(ctx.Command().GetOptLocalBranchName(), version, ctx)
+	if err != nil {
+		return "", err
+	}
+
+	remoteBranchName, err := replaceInBranchPattern(ctx.Command().GetOptRemoteBranchName(), version, ctx)
+	if err != nil {
+		return "", err
+	}
+
+	remoteTrackingBranchName := "remotes/" + ctx.Command().GetOptRemote() + "/" + remoteBranchName
+
+	localExists, err := ctx.Git().BranchExists(localBranchName)
+	if err != nil {
+		return "", fmt.Errorf("error checking if branch exists: %w", err)
+	}
+
+	remoteExists, err := ctx.Git().BranchExists(remoteTrackingBranchName)
+	if err != nil {
+		return "", fmt.Errorf("error checking if branch exists: %w", err)
+	}
+
+	onRemote, err := existsOnRemote("refs/heads/"+remoteBranchName, ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if localExists || remoteExists || onRemote {
+		return "", newError(ErrAlreadyExists, "branch %s already exists", localBranchName)
+	}
+
+	return localBranchName, nil
+}
+
+// existsOnRemote checks the remote itself for a ref with --remote-only, so a
+// release someone else created since the last fetch is not created again
+func existsOnRemote(refName string, ctx interfaces.GitRelContext) (bool, error) {
+	if !ctx.Command().GetOptRemoteOnly() {
+		return false, nil
+	}
+
+	refs, err := ctx.Git().ListRemoteRefs(ctx.Command().GetOptRemote())
+	if err != nil {
+		return false, fmt.Errorf("error listing remote refs: %w", err)
+	}
+
+	return slices.ContainsFunc(refs, func(ref interfaces.Ref) bool { return ref.Name == refName }), nil
+}
+
+// pushReleaseBranch pushes a local release branch to its remote branch name
+func pushReleaseBranch(localBranchName string, version semver.Version, ctx interfaces.GitRelContext) error {
+	remoteBranchName, err := replaceInBranchPattern(ctx.Command().GetOptRemoteBranchName(), version, ctx)
+	if err != nil {
+		return err
+	}
+
+	if localBranchName != remoteBranchName {
+		reportChange(ctx, "Pushing %v to %v (%v)...\n", "Would push %v to %v (%v)\n", localBranchName, ctx.Command().GetOptRemote(), remoteBranchName)
+	} else {
+		reportChange(ctx, "Pushing %v to %v...\n", "Would push %v to %v\n", localBranchName, ctx.Command().GetOptRemote())
+	}
+
+	err = ctx.Git().PushBranch(ctx.Command().GetOptRemote(), localBranchName+":"+remoteBranchName)
+	if err != nil {
+		return err
+	}
+
+	reportPushed(ctx)
+
+	return tagPushedRelease(localBranchName, version, ctx)
+}
+
+// tagPushedRelease marks the commit just pushed to a release branch with an
+// annotated tag, if tagging on release is configured
+func tagPushedRelease(localBranchName string, version semver.Version, ctx interfaces.GitRelContext) error {
+	tagTemplate := ctx.Command().GetOptTagOnRelease()
+	if tagTemplate == "" {
+		return nil
+	}
+
+	tags, err := ctx.Git().ListTags()
+	if err != nil {
+		return fmt.Errorf("error listing tags: %w", err)
+	}
+
+	sequence, err := nextTagSequence(tagTemplate, version, tags, ctx)
+	if err != nil {
+		return err
+	}
+
+	tagName, err := renderTagTemplate(tagTemplate, version, sequence, ctx)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(tags, tagName) {
+		ctx.Output().Printf("Tag %v already exists, not tagging\n", tagName)
+		return nil
+	}
+
+	message, err := renderTagTemplate(ctx.Command().GetOptTagMessage(), version, sequence, ctx)
+	if err != nil {
+		return err
+	}
+
+	err = ctx.Git().CreateTag(tagName, localBranchName, message)
+	if err != nil {
+		return fmt.Errorf("error creating tag: %w", err)
+	}
+
+	reportChange(ctx, "Tagged %v as %v\n", "Would tag %v as %v\n", localBranchName, tagName)
+	return pushReleaseTag(tagName, ctx)
+}
+
+// tagPushError is a failed push of a tag made by tagOnRelease, which is kept
+// so that continuing pushes the same tag rather than tagging again
+type tagPushError struct {
+	tagName string
+	err     error
+}
+
+func (e *tagPushError) Error() string {
+	return e.err.Error()
+}
+
+func (e *tagPushError) Unwrap() error {
+	return e.err
+}
+
+// pushReleaseTag pushes a tag made by tagOnRelease
+func pushReleaseTag(tagName string, ctx interfaces.GitRelContext) error {
+	reportChange(ctx, "Pushing tag %v to %v...\n", "Would push tag %v to %v\n", tagName, ctx.Command().GetOptRemote())
+	err := ctx.Git().PushTag(ctx.Command().GetOptRemote(), tagName)
+	if err != nil {
+		return &tagPushError{tagName: tagName, err: err}
+	}
+
+	reportPushed(ctx)
+	return nil
+}
+
+const updateStateName = "update"
+
+// updateState is the progress of an update, saved when its merge or push
+// fails so the update can be continued or aborted
+type updateState struct {
+	OriginalBranch string         `json:"originalBranch"`
+	Branch         string         `json:"branch"`
+	Version        semver.Version `json:"version"`
+	CreatedBranch  bool           `json:"createdBranch"`
+	Merged         bool           `json:"merged"`
+	// PendingTag is a tag made by tagOnRelease that failed to push
+	PendingTag string `json:"pendingTag,omitempty"`
+	// Steps are the journal of the invocations that stopped, so undo can
+	// reverse the whole update once it is continued
+	Steps []journalStep `json:"steps,omitempty"`
+}
+
+// Function to merge the current branch into a release branch and push it,
+// returning the version of the release, once it is known, even on failure
+func UpdateVersion(versionish string, ctx interfaces.GitRelContext) (semver.Version, error) {
+	var version semver.Version
+	err := inTransaction("update "+versionish, ctx, func(ctx interfaces.GitRelContext) error {
+		var err error
+		version, err = updateVersion(versionish, ctx)
+		return err
+	})
+	if err != nil {
+		return version, err
+	}
+
+	return version, writeChangelogHook(version, ctx)
+}
+
+// updateVersion returns the version of the release it updated, or was
+// updating when it stopped
+func updateVersion(versionish string, ctx interfaces.GitRelContext) (semver.Version, error) {
+	// Validate that the version is a valid semantic version && has a branch
+	var version semver.Version
+	if versionish != "latest" {
+		var err error
+		version, err = semver.Parse(versionish)
+		if err != nil && usesLines(ctx) {
+			version, err = semver.ParseLine(versionish, ctx.Command().GetOptLine())
+		}
+
+		if err != nil {
+			return semver.Version{}, err
+		}
+	}
+
+	inProgress, err := loadOperationState(updateStateName, &updateState{}, ctx)
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	if inProgress {
+		return semver.Version{}, newError(ErrAlreadyExists, "an update is already in progress. run 'gitrel update --continue' or 'gitrel update --abort'")
+	}
+
+	hasUncommittedChanges, err := ctx.Git().HasUncommittedChanges()
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	if hasUncommittedChanges {
+		return semver.Version{}, newError(ErrDirtyWorktree, "you have uncommitted changes. please commit or stash them before updating")
+	}
+
+	releases, err := getReleases(ctx)
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	var release *ReleaseInfo
+	if versionish == "latest" {
+		if len(releases) > 0 {
+			release = releases[len(releases)-1]
+		}
+	} else {
+		for _, r := range releases {
+			if isSameRelease(r, version, ctx) {
+				release = r
+			}
+		}
+	}
+
+	if release == nil {
+		return semver.Version{}, newError(ErrNotFound, "no release branch found for version: %s", versionish)
+	}
+
+	if !release.HasBranch() {
+		return semver.Version{}, newError(ErrNotFound, "release %s has no release branch to update", release.Version)
+	}
+
+	// Get the current branch
+	currentBranch, err := ctx.Git().GetCurrentBranch()
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	// Check out the branch for the version
+	createdBranch := release.GetFirstLocalBranch() == nil
+	localBranch, err := getOrCreateLocalBranch(release, ctx)
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	localBranchName := localBranch.BranchName
+	ctx.Output().Printf("Checking out %v...\n", localBranchName)
+
+	err = ctx.Git().CheckoutBranch(localBranchName)
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	state := &updateState{
+		OriginalBranch: currentBranch,
+		Branch:         localBranchName,
+		Version:        release.Version,
+		CreatedBranch:  createdBranch,
+	}
+
+	// Merge in the original branch
+	ctx.Output().Printf("Merging %v into %v...\n", currentBranch, localBranchName)
+	err = ctx.Git().MergeBranch(currentBranch)
+	if err != nil {
+		return release.Version, stopUpdate(state, fmt.Errorf("error merging %s into %s: %w", currentBranch, localBranchName, err), ctx)
+	}
+
+	state.Merged = true
+
+	// Push the changes
+	err = pushReleaseBranch(localBranchName, release.Version, ctx)
+	if err != nil {
+		return release.Version, stopUpdate(state, err, ctx)
+	}
+
+	// Switch back to the original branch
+	err = ctx.Git().SwitchBack()
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	reportChange(ctx, "Switched back to branch: %v\n", "Would switch back to branch: %v\n", currentBranch)
+	return release.Version, nil
+}
+
+// stopUpdate saves the state of an update that failed part way, leaving the
+// release branch checked out so the problem can be fixed rather than rolling
+// the update back
+func stopUpdate(state *updateState, err error, ctx interfaces.GitRelContext) error {
+	var tagErr *tagPushError
+	if errors.As(err, &tagErr) {
+		state.PendingTag = tagErr.tagName
+	}
+
+	state.Steps = append(state.Steps, journaledSteps(ctx)...)
+
+	saveErr := saveOperationState(updateStateName, state, ctx)
+	if saveErr != nil {
+		return errors.Join(err, saveErr)
+	}
+
+	if !state.Merged {
+		return &suspendedError{fmt.Errorf("%w\nresolve the conflicts, then run 'gitrel update --continue', or 'gitrel update --abort' to stop", err)}
+	}
+
+	return &suspendedError{fmt.Errorf("%w\nrun 'gitrel update --continue' to retry the push, or 'gitrel update --abort' to stop", err)}
+}
+
+// Function to finish an update stopped by a failed merge or push, committing
+// the resolved merge, pushing, and switching back to the original branch. It
+// returns the version of the release being updated.
+func ContinueUpdate(ctx interfaces.GitRelContext) (semver.Version, error) {
+	state := &updateState{}
+	inProgress, err := loadOperationState(updateStateName, state, ctx)
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	if !inProgress {
+		return semver.Version{}, newError(ErrNotFound, "no update in progress")
+	}
+
+	suspendedSteps := slices.Clone(state.Steps)
+	err = resumeTransaction("update "+state.Branch, suspendedSteps, ctx, func(ctx interfaces.GitRelContext) error {
+		return continueUpdate(state, ctx)
+	})
+	if err != nil {
+		return state.Version, err
+	}
+
+	return state.Version, writeChangelogHook(state.Version, ctx)
+}
+
+func continueUpdate(state *updateState, ctx interfaces.GitRelContext) error {
+	ctx.Output().Printf("Continuing update of %v...\n", state.Branch)
+	if !state.Merged {
+		err := ctx.Git().ContinueMerge()
+		if err != nil {
+			// The merge is still in progress, so leave it to be resolved
+			return &suspendedError{fmt.Errorf("error continuing merge into %s: %w", state.Branch, err)}
+		}
+
+		state.Merged = true
+	}
+
+	// The branch was pushed if only its tag failed to push
+	var err error
+	if state.PendingTag != "" {
+		err = pushReleaseTag(state.PendingTag, ctx)
+	} else {
+		err = pushReleaseBranch(state.Branch, state.Version, ctx)
+	}
+
+	if err != nil {
+		return stopUpdate(state, err, ctx)
+	}
+
+	err = clearOperationState(updateStateName, ctx)
+	if err != nil {
+		return err
+	}
+
+	err = ctx.Git().CheckoutBranch(state.OriginalBranch)
+	if err != nil {
+		return err
+	}
+
+	reportChange(ctx, "Switched back to branch: %v\n", "Would switch back to branch: %v\n", state.OriginalBranch)
+	return nil
+}
+
+// Function to abort an update stopped by a failed merge or push, deleting the
+// local release branch if the update created it. A merge that was already
+// committed is left on the release branch.
+func AbortUpdate(ctx interfaces.GitRelContext) error {
+	state := &updateState{}
+	inProgress, err := loadOperationState(updateStateName, state, ctx)
+	if err != nil {
+		return err
+	}
+
+	if !inProgress {
+		return newError(ErrNotFound, "no update in progress")
+	}
+
+	if !state.Merged {
+		err = ctx.Git().AbortMerge()
+		if err != nil {
+			return fmt.Errorf("error aborting merge: %w", err)
+		}
+	}
+
+	err = ctx.Git().CheckoutBranch(state.OriginalBranch)
+	if err != nil {
+		return err
+	}
+
+	if state.CreatedBranch {
+		err = ctx.Git().DeleteBranch(state.Branch)
+		if err != nil {
+			return fmt.Errorf("error deleting branch %s: %w", state.Branch, err)
+		}
 
-	localBranchName := replaceInBranchPattern(ctx.Command().GetOptLocalBranchName(), version)
-	remoteTrackingBranchName := replaceInBranchPattern("remotes/"+ctx.Command().GetOptRemote()+"/"+ctx.Command().GetOptRemoteBranchName(), version)
+		reportChange(ctx, "Deleted branch: %v\n", "Would delete branch: %v\n", state.Branch)
+	}
 
-	localExists, err := ctx.Git().BranchExists(localBranchName)
+	err = clearOperationState(updateStateName, ctx)
 	if err != nil {
-		return fmt.Errorf("error checking if branch exists: %w", err)
+		return err
 	}
 
-	remoteExists, err := ctx.Git().BranchExists(remoteTrackingBranchName)
+	reportChange(ctx, "Aborted update of %v\n", "Would abort update of %v\n", state.Branch)
+	reportChange(ctx, "Switched back to branch: %v\n", "Would switch back to branch: %v\n", state.OriginalBranch)
+	return nil
+}
+
+// Function to merge a release forward into the latest release of each newer
+// release line in turn, finishing on the default branch. Stops at the first
+// hop that fails, aborting its merge.
+func CascadeVersion(prefix string, ctx interfaces.GitRelContext) error {
+	hasUncommittedChanges, err := ctx.Git().HasUncommittedChanges()
 	if err != nil {
-		return fmt.Errorf("error checking if branch exists: %w", err)
+		return err
 	}
 
-	if localExists || remoteExists {
-		return fmt.Errorf("branch %s already exists", localBranchName)
+	if hasUncommittedChanges {
+		return newError(ErrDirtyWorktree, "you have uncommitted changes. please commit or stash them before cascading")
 	}
 
-	err = ctx.Git().SwitchToNewBranch(localBranchName)
+	releases, err := getReleases(ctx)
 	if err != nil {
 		return err
 	}
 
-	ctx.Output().Printf("Created new release branch: %s\n", localBranchName)
+	fromRelease, err := findLatestRelease(prefix, releases)
+	if err != nil {
+		return err
+	}
 
-	remoteBranchName := replaceInBranchPattern(ctx.Command().GetOptRemoteBranchName(), version)
+	if !fromRelease.HasBranch() {
+		return newError(ErrNotFound, "release %s has no release branch to cascade from", fromRelease.Version)
+	}
 
-	if localBranchName != remoteBranchName {
-		ctx.Output().Printf("Pushing %v to %v (%v)...\n", localBranchName, ctx.Command().GetOptRemote(), remoteBranchName)
-	} else {
-		ctx.Output().Printf("Pushing %v to %v...\n", localBranchName, ctx.Command().GetOptRemote())
+	originalBranch, err := ctx.Git().GetCurrentBranch()
+	if err != nil {
+		return err
 	}
 
-	err = ctx.Git().PushBranch(ctx.Command().GetOptRemote(), localBranchName+":"+remoteBranchName)
+	defaultBranch, err := ctx.Git().GetDefaultBranch(ctx.Command().GetOptRemote())
 	if err != nil {
 		return err
 	}
 
-	ctx.Output().Println("Pushed!")
+	targets := getCascadeTargets(fromRelease, releases, ctx)
 
-	err = ctx.Git().SwitchBack()
+	chain := []string{fromRelease.Version.String()}
+	for _, target := range targets {
+		chain = append(chain, target.Version.String())
+	}
+	chain = append(chain, defaultBranch)
+	ctx.Output().Printf("Cascading %s\n", strings.Join(chain, " -> "))
+
+	// Leave the user where they started, whether or not the cascade completes
+	switchBack := func(err error) error {
+		checkoutErr := ctx.Git().CheckoutBranch(originalBranch)
+		if checkoutErr != nil {
+			return errors.Join(err, checkoutErr)
+		}
+
+		reportChange(ctx, "Switched back to branch: %v\n", "Would switch back to branch: %v\n", originalBranch)
+		return err
+	}
+
+	sourceBranch, err := getOrCreateLocalBranch(fromRelease, ctx)
 	if err != nil {
 		return err
 	}
 
-	curBranch, err := ctx.Git().GetCurrentBranch()
+	source := sourceBranch.BranchName
+	hops := len(targets) + 1
+	for i, target := range targets {
+		targetBranch, err := getOrCreateLocalBranch(target, ctx)
+		if err != nil {
+			return switchBack(err)
+		}
+
+		err = mergeCascadeHop(i+1, hops, source, targetBranch.BranchName, ctx)
+		if err != nil {
+			return switchBack(err)
+		}
+
+		err = pushReleaseBranch(targetBranch.BranchName, target.Version, ctx)
+		if err != nil {
+			return switchBack(err)
+		}
+
+		source = targetBranch.BranchName
+	}
+
+	err = mergeCascadeHop(hops, hops, source, defaultBranch, ctx)
+	if err != nil {
+		return switchBack(err)
+	}
+
+	reportChange(ctx, "Pushing %v to %v...\n", "Would push %v to %v\n", defaultBranch, ctx.Command().GetOptRemote())
+	err = ctx.Git().PushBranch(ctx.Command().GetOptRemote(), defaultBranch)
+	if err != nil {
+		return switchBack(err)
+	}
+
+	reportPushed(ctx)
+	return switchBack(nil)
+}
+
+// getCascadeTargets returns the latest release of each release line newer than
+// the given release, in order. Lines are minor versions unless major or minor
+// release lines are configured.
+func getCascadeTargets(fromRelease *ReleaseInfo, releases []*ReleaseInfo, ctx interfaces.GitRelContext) []*ReleaseInfo {
+	granularity := LineMinor
+	if usesLines(ctx) {
+		granularity = ctx.Command().GetOptLine()
+	}
+
+	targets := []*ReleaseInfo{}
+	for _, r := range releases {
+		if !fromRelease.Version.LessThan(r.Version) || !r.HasBranch() {
+			continue
+		}
+
+		// Releases are ordered, so a later release on the same line replaces
+		// the previous one
+		last := len(targets) - 1
+		if last >= 0 && targets[last].Version.LineBase(granularity) == r.Version.LineBase(granularity) {
+			targets[last] = r
+		} else {
+			targets = append(targets, r)
+		}
+	}
+
+	return targets
+}
+
+// mergeCascadeHop checks out the target branch and merges the source into it,
+// aborting the merge if it fails
+func mergeCascadeHop(hop int, hops int, source string, target string, ctx interfaces.GitRelContext) error {
+	ctx.Output().Printf("[%d/%d] Merging %v into %v...\n", hop, hops, source, target)
+
+	err := ctx.Git().CheckoutBranch(target)
 	if err != nil {
 		return err
 	}
 
-	ctx.Output().Printf("Switched back to branch: %s\n", curBranch)
+	err = ctx.Git().MergeBranch(source)
+	if err != nil {
+		abortErr := ctx.Git().AbortMerge()
+		if abortErr != nil {
+			err = errors.Join(err, abortErr)
+		}
+
+		return fmt.Errorf("cascade stopped at hop %d of %d, merging %s into %s: %w", hop, hops, source, target, err)
+	}
 
 	return nil
 }
 
-func UpdateVersion(versionish string, ctx interfaces.GitRelContext) error {
-	// Validate that the version is a valid semantic version && has a branch
-	if !(semver.ValidateSemver(versionish) || versionish == "latest") {
-		return fmt.Errorf("invalid version format. please use semantic versioning (e.g., 1.0.0, 1.2.3-alpha, 2.0.0+build.1) or 'latest'")
+const backportStateName = "backport"
+
+// backportState is the progress of a backport, saved when a cherry-pick stops
+// on a conflict
+type backportState struct {
+	OriginalBranch string           `json:"originalBranch"`
+	Commits        []string         `json:"commits"`
+	Targets        []backportTarget `json:"targets"`
+	Target         int              `json:"target"`
+	Commit         int              `json:"commit"`
+	Results        []backportResult `json:"results"`
+}
+
+type backportTarget struct {
+	Branch  string         `json:"branch"`
+	Version semver.Version `json:"version"`
+}
+
+type backportResult struct {
+	Branch string `json:"branch"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Function to cherry-pick commits onto the latest release branch matching each
+// of the target version prefixes, pushing each release branch once all of the
+// commits are picked. Stops at the first conflict so it can be resolved and
+// continued with ContinueBackport.
+func BackportCommits(commits []string, prefixes []string, ctx interfaces.GitRelContext) error {
+	inProgress, err := loadOperationState(backportStateName, &backportState{}, ctx)
+	if err != nil {
+		return err
+	}
+
+	if inProgress {
+		return newError(ErrAlreadyExists, "a backport is already in progress. run 'gitrel backport --continue' or 'gitrel backport --abort'")
 	}
 
 	hasUncommittedChanges, err := ctx.Git().HasUncommittedChanges()
@@ -85,7 +903,19 @@ func UpdateVersion(versionish string, ctx interfaces.GitRelContext) error {
 	}
 
 	if hasUncommittedChanges {
-		return fmt.Errorf("you have uncommitted changes. please commit or stash them before updating")
+		return newError(ErrDirtyWorktree, "you have uncommitted changes. please commit or stash them before backporting")
+	}
+
+	// Resolve commits before switching branches, so HEAD and branch names
+	// refer to what the user is looking at now
+	resolved := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		hash, err := ctx.Git().ResolveCommit(commit)
+		if err != nil {
+			return err
+		}
+
+		resolved = append(resolved, hash)
 	}
 
 	releases, err := getReleases(ctx)
@@ -93,73 +923,178 @@ func UpdateVersion(versionish string, ctx interfaces.GitRelContext) error {
 		return err
 	}
 
-	var release *ReleaseInfo
-	if versionish == "latest" {
-		release = releases[len(releases)-1]
-	} else {
-		for _, r := range releases {
-			if r.Version == versionish {
-				release = r
-			}
+	targets := []backportTarget{}
+	for _, prefix := range prefixes {
+		release, err := findLatestRelease(strings.TrimSpace(prefix), releases)
+		if err != nil {
+			return err
+		}
+
+		if !release.HasBranch() {
+			return newError(ErrNotFound, "release %s has no release branch to backport to", release.Version)
+		}
+
+		branch, err := getOrCreateLocalBranch(release, ctx)
+		if err != nil {
+			return err
+		}
+
+		if !slices.ContainsFunc(targets, func(t backportTarget) bool { return t.Branch == branch.BranchName }) {
+			targets = append(targets, backportTarget{Branch: branch.BranchName, Version: release.Version})
 		}
 	}
 
-	if release == nil {
-		return fmt.Errorf("no release branch found for version: %s", versionish)
+	originalBranch, err := ctx.Git().GetCurrentBranch()
+	if err != nil {
+		return err
 	}
 
-	// Get the current branch
-	currentBranch, err := ctx.Git().GetCurrentBranch()
+	state := &backportState{
+		OriginalBranch: originalBranch,
+		Commits:        resolved,
+		Targets:        targets,
+		Results:        []backportResult{},
+	}
+
+	return runBackport(state, ctx)
+}
+
+// Function to continue a backport stopped by a conflict, once the conflict is
+// resolved and staged
+func ContinueBackport(ctx interfaces.GitRelContext) error {
+	state := &backportState{}
+	inProgress, err := loadOperationState(backportStateName, state, ctx)
 	if err != nil {
 		return err
 	}
 
-	// Check out the branch for the version
-	localBranch, err := getOrCreateLocalBranch(release, ctx)
+	if !inProgress {
+		return newError(ErrNotFound, "no backport in progress")
+	}
+
+	target := state.Targets[state.Target]
+	ctx.Output().Printf("Continuing backport to %v...\n", target.Branch)
+	err = ctx.Git().ContinueCherryPick()
+	if err != nil {
+		return fmt.Errorf("error continuing cherry-pick onto %s: %w", target.Branch, err)
+	}
+
+	state.Commit++
+	return runBackport(state, ctx)
+}
+
+// Function to abort a backport stopped by a conflict. Release branches that
+// were already pushed are left as they are.
+func AbortBackport(ctx interfaces.GitRelContext) error {
+	state := &backportState{}
+	inProgress, err := loadOperationState(backportStateName, state, ctx)
 	if err != nil {
 		return err
 	}
 
-	localBranchName := localBranch.BranchName
-	ctx.Output().Printf("Checking out %v...\n", localBranchName)
+	if !inProgress {
+		return newError(ErrNotFound, "no backport in progress")
+	}
 
-	err = ctx.Git().CheckoutBranch(localBranchName)
+	err = ctx.Git().AbortCherryPick()
+	if err != nil {
+		return fmt.Errorf("error aborting cherry-pick: %w", err)
+	}
+
+	err = ctx.Git().CheckoutBranch(state.OriginalBranch)
 	if err != nil {
 		return err
 	}
 
-	// Merge in the original branch
-	ctx.Output().Printf("Merging %v into %v...\n", currentBranch, localBranchName)
-	err = ctx.Git().MergeBranch(currentBranch)
+	err = clearOperationState(backportStateName, ctx)
 	if err != nil {
 		return err
 	}
 
-	// Push the changes
-	remoteBranchName := replaceInBranchPattern(ctx.Command().GetOptRemoteBranchName(), release.Version)
-	if localBranchName != remoteBranchName {
-		ctx.Output().Printf("Pushing %v to %v (%v)...\n", localBranchName, ctx.Command().GetOptRemote(), remoteBranchName)
-	} else {
-		ctx.Output().Printf("Pushing %v to %v...\n", localBranchName, ctx.Command().GetOptRemote())
+	reportChange(ctx, "Aborted backport to %v\n", "Would abort backport to %v\n", state.Targets[state.Target].Branch)
+	reportChange(ctx, "Switched back to branch: %v\n", "Would switch back to branch: %v\n", state.OriginalBranch)
+	printBackportResults(state.Results, ctx)
+	return nil
+}
+
+// runBackport picks the remaining commits onto the remaining targets. A
+// target that cannot be checked out or pushed is reported as failed without
+// stopping the others, but a failed cherry-pick saves the state and stops.
+func runBackport(state *backportState, ctx interfaces.GitRelContext) error {
+	for ; state.Target < len(state.Targets); state.Target++ {
+		target := state.Targets[state.Target]
+
+		if state.Commit == 0 {
+			ctx.Output().Printf("Backporting %d commit(s) to %v...\n", len(state.Commits), target.Branch)
+			err := ctx.Git().CheckoutBranch(target.Branch)
+			if err != nil {
+				state.Results = append(state.Results, backportResult{Branch: target.Branch, Error: err.Error()})
+				continue
+			}
+		}
+
+		for ; state.Commit < len(state.Commits); state.Commit++ {
+			err := ctx.Git().CherryPick(state.Commits[state.Commit])
+			if err != nil {
+				saveErr := saveOperationState(backportStateName, state, ctx)
+				if saveErr != nil {
+					return errors.Join(err, saveErr)
+				}
+
+				return fmt.Errorf("error cherry-picking %s onto %s: %w\nresolve the conflicts, then run 'gitrel backport --continue', or 'gitrel backport --abort' to stop", state.Commits[state.Commit], target.Branch, err)
+			}
+		}
+
+		state.Commit = 0
+		err := pushReleaseBranch(target.Branch, target.Version, ctx)
+		if err != nil {
+			state.Results = append(state.Results, backportResult{Branch: target.Branch, Error: err.Error()})
+			continue
+		}
+
+		state.Results = append(state.Results, backportResult{Branch: target.Branch})
 	}
 
-	err = ctx.Git().PushBranch(ctx.Command().GetOptRemote(), localBranchName+":"+remoteBranchName)
+	err := clearOperationState(backportStateName, ctx)
 	if err != nil {
 		return err
 	}
 
-	ctx.Output().Println("Pushed!")
-
-	// Switch back to the original branch
-	err = ctx.Git().SwitchBack()
+	err = ctx.Git().CheckoutBranch(state.OriginalBranch)
 	if err != nil {
 		return err
 	}
 
-	ctx.Output().Printf("Switched back to branch: %v\n", currentBranch)
+	reportChange(ctx, "Switched back to branch: %v\n", "Would switch back to branch: %v\n", state.OriginalBranch)
+	failed := printBackportResults(state.Results, ctx)
+	if failed > 0 {
+		return fmt.Errorf("backport failed for %d of %d releases", failed, len(state.Results))
+	}
+
 	return nil
 }
 
+// printBackportResults prints the outcome for each target, returning the
+// number that failed
+func printBackportResults(results []backportResult, ctx interfaces.GitRelContext) int {
+	if len(results) == 0 {
+		return 0
+	}
+
+	failed := 0
+	ctx.Output().Println("Backport results:")
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+			ctx.Output().Printf(" - %v: failed (%v)\n", result.Branch, result.Error)
+		} else {
+			ctx.Output().Printf(" - %v: backported\n", result.Branch)
+		}
+	}
+
+	return failed
+}
+
 // Function to checkout the latest release branch matching the specified version prefix
 func CheckoutVersion(prefix string, ctx interfaces.GitRelContext) error {
 	releases, err := getReleases(ctx)
@@ -167,29 +1102,25 @@ func CheckoutVersion(prefix string, ctx interfaces.GitRelContext) error {
 		return err
 	}
 
-	var matchingReleases []*ReleaseInfo
-	for _, release := range releases {
-		if prefix == "latest" || strings.HasPrefix(release.Version, prefix) {
-			matchingReleases = append(matchingReleases, release)
-		}
+	latestRelease, err := findLatestRelease(prefix, releases)
+	if err != nil {
+		return err
 	}
 
-	if len(matchingReleases) == 0 {
-		return fmt.Errorf("no release branches found matching prefix: %s", prefix)
+	if !latestRelease.HasBranch() {
+		tagName := latestRelease.GetFirstTag().BranchName
+		ctx.Output().Printf("Checking out release tag: %s\n", tagName)
+		return ctx.Git().CheckoutBranch(tagName)
 	}
 
-	sort.Slice(matchingReleases, func(i, j int) bool {
-		return semver.CompareSemver(matchingReleases[i].Version, matchingReleases[j].Version)
-	})
-
-	latestRelease := matchingReleases[len(matchingReleases)-1]
-
 	branch, err := getOrCreateLocalBranch(latestRelease, ctx)
 	if err != nil {
 		return err
 	}
 
 	branchName := branch.BranchName
+	ctx.Output().Printf("Checking out release branch: %s\n", branchName)
+
 	err = ctx.Git().CheckoutBranch(branchName)
 	if err != nil {
 		return err
@@ -199,125 +1130,313 @@ func CheckoutVersion(prefix string, ctx interfaces.GitRelContext) error {
 }
 
 // Function to show status
-func ShowStatus(ctx interfaces.GitRelContext) {
+func ShowStatus(ctx interfaces.GitRelContext) error {
+	status, err := GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx.Output().Emit(status)
+	return nil
+}
+
+// Function to get the current and latest versions, and every release
+func GetStatus(ctx interfaces.GitRelContext) (*Status, error) {
 	releases, err := getReleases(ctx)
 	if err != nil {
-		ctx.Output().Println(err)
-		return
+		return nil, err
+	}
+
+	status := &Status{
+		Remote:   ctx.Command().GetOptRemote(),
+		Releases: []ReleaseSummary{},
 	}
 
 	if len(releases) == 0 {
-		ctx.Output().Println("No existing release branches found.")
-		ctx.Output().Println("Remote:", ctx.Command().GetOptRemote())
-		return
+		return status, nil
 	}
 
-	currentVersion := getCurrentVersionFromBranch(ctx)
-	if currentVersion != "" {
-		ctx.Output().Println("Current version:", currentVersion)
-	} else {
-		ctx.Output().Println("Current version: (not on a release branch)")
+	currentVersion, onRelease := getCurrentVersionFromBranch(ctx)
+
+	var currentRelease *ReleaseInfo
+	for _, r := range releases {
+		if onRelease && isSameRelease(r, currentVersion, ctx) {
+			currentRelease = r
+			currentVersion = r.Version
+		}
+	}
+
+	if onRelease {
+		status.Current = currentVersion.String()
 	}
 
 	latestVersion := releases[len(releases)-1].Version
-	ctx.Output().Println("Latest version:", latestVersion)
-	ctx.Output().Println("Remote:", ctx.Command().GetOptRemote())
-	ctx.Output().Println("Other versions:")
+	status.Latest = latestVersion.String()
 
-	type ReleaseMetadata struct {
-		Version string
-		Tags []string
+	for i := len(releases) - 1; i >= 0; i-- {
+		summary := newReleaseSummary(releases[i], ctx)
+		summary.Latest = releases[i].Version.Compare(latestVersion) == 0
+		summary.Current = releases[i] == currentRelease
+		status.Releases = append(status.Releases, summary)
 	}
 
-	releaseMetadata := []ReleaseMetadata{}
-	for i := len(releases) - 1; i >= 0; i-- {
-		tags := []string{}
+	return status, nil
+}
+
+// DescribeCommit finds the releases containing a commit and the nearest
+// release it was made after, and gives it a build version from them
+func DescribeCommit(commitish string, ctx interfaces.GitRelContext) (*Description, error) {
+	commit, err := ctx.Git().ResolveCommit(commitish)
+	if err != nil {
+		return nil, err
+	}
+
+	releases, err := getVersionReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	description := &Description{
+		Commit:      commit,
+		ContainedIn: []string{},
+	}
+
+	var nearest *ReleaseInfo
+	for _, release := range releases {
+		contained, err := releaseContains(release, commit, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if contained {
+			description.ContainedIn = append(description.ContainedIn, release.Version.String())
+		}
 
-		if releases[i].Version == latestVersion {
-			tags = append(tags, "latest")
+		point := release.GetReleasePoint()
+		if point == nil {
+			continue
+		}
+
+		isAncestor, err := ctx.Git().IsAncestor(point.BranchName, commit)
+		if errors.Is(err, ErrNotFound) || (err == nil && !isAncestor) {
+			continue
 		}
-		
-		if releases[i].Version == currentVersion {
-			tags = append(tags, "current")
+
+		if err != nil {
+			return nil, err
 		}
 
-		md := ReleaseMetadata{
-			Version: releases[i].Version,
-			Tags: tags,
+		distance, err := ctx.Git().CountCommits(point.BranchName, commit)
+		if err != nil {
+			return nil, err
 		}
 
-		releaseMetadata = append(releaseMetadata, md)
+		// Releases are in order, so the highest version wins a tie
+		if nearest == nil || distance <= description.Distance {
+			nearest = release
+			description.Distance = distance
+		}
 	}
 
-	maxI := len(releaseMetadata) - 1
-	skippedVersion := false
-	for i, md := range releaseMetadata {
-		// Display versions on either side of a tag, and tagged versions themselves
-		print := false
-		if i < maxI && len(releaseMetadata[i+1].Tags) > 0 {
-			print = true
-		} else if i > 0 && len(releaseMetadata[i-1].Tags) > 0 {
-			print = true
-		} else if len(md.Tags) > 0 {
-			print = true
+	if nearest == nil {
+		description.Distance, err = ctx.Git().CountCommits("", commit)
+		if err != nil {
+			return nil, err
 		}
 
-		if !print {
-			skippedVersion = true
+		description.BuildVersion = buildVersion(semver.Version{}, description.Distance, commit).String()
+		return description, nil
+	}
+
+	description.Nearest = nearest.Version.String()
+	description.BuildVersion = buildVersion(nearest.Version, description.Distance, commit).String()
+	return description, nil
+}
+
+// releaseContains checks whether any branch or tag of a release contains a
+// commit. Refs that only exist on the remote are skipped.
+func releaseContains(release *ReleaseInfo, commit string, ctx interfaces.GitRelContext) (bool, error) {
+	for _, branch := range release.Branches {
+		contained, err := ctx.Git().IsAncestor(commit, branch.BranchName)
+		if errors.Is(err, ErrNotFound) {
 			continue
 		}
 
-		if skippedVersion {
-			ctx.Output().Println(" - ...")
-			skippedVersion = false
+		if err != nil || contained {
+			return contained, err
 		}
+	}
 
-		if len(md.Tags) > 0 {
-			ctx.Output().Printf(" - %s (%s)\n", md.Version, strings.Join(md.Tags, ", "))
-		} else {
-			ctx.Output().Printf(" - %s\n", md.Version)
-		}
+	return false, nil
+}
+
+// buildVersion is the version of a commit some distance after a release: the
+// release itself at no distance, otherwise a dev prerelease of the next patch
+// with the commit as build metadata, e.g. 1.2.3-dev.5+gabc1234. A commit after
+// a prerelease gets a dev prerelease of that, e.g. 2.0.0-rc.1.dev.5+gabc1234.
+func buildVersion(release semver.Version, distance int, commit string) semver.Version {
+	if distance == 0 && !release.IsZero() {
+		return release
+	}
+
+	version := semver.Version{Major: release.Major, Minor: release.Minor, Patch: release.Patch + 1}
+	if release.IsZero() {
+		version.Patch = 0
 	}
 
-	if skippedVersion {
-		ctx.Output().Println(" - ...")
+	version.Prerelease = fmt.Sprintf("dev.%d", distance)
+	if release.IsPrerelease() {
+		version = release.Release()
+		version.Prerelease = fmt.Sprintf("%s.dev.%d", release.Prerelease, distance)
 	}
+
+	version.Build = "g" + shortSHA(commit)
+	return version
 }
 
 // Function to increment and create a new branch
-func IncrementAndCreateBranch(part string, ctx interfaces.GitRelContext) {
-	highestVersion := getHighestVersion(ctx)
-	newVersion := ""
-	if highestVersion == "0.0.0" {
+func IncrementAndCreateBranch(part string, from string, ctx interfaces.GitRelContext) (semver.Version, error) {
+	highestVersion, err := getHighestVersion(ctx)
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	var newVersion semver.Version
+	if highestVersion.IsZero() {
 		if part == "major" {
-			newVersion = "1.0.0"
+			newVersion = semver.Version{Major: 1}
 		} else if part == "patch" {
-			newVersion = "0.0.1"
+			newVersion = semver.Version{Patch: 1}
 		} else {
 			// default to a minor version
-			newVersion = "0.1.0"
+			newVersion = semver.Version{Minor: 1}
 		}
 	} else {
-		newVersion = semver.IncrementVersion(highestVersion, part)
+		newVersion, err = highestVersion.Increment(part)
+		if err != nil {
+			return semver.Version{}, err
+		}
+	}
+
+	return newVersion, CreateRelease(newVersion, from, ctx)
+}
+
+// AutoIncrementAndCreateBranch reads the commits since the latest release as
+// Conventional Commits, showing the part each one bumps, and creates the
+// release for the most significant of them
+func AutoIncrementAndCreateBranch(from string, ctx interfaces.GitRelContext) (semver.Version, error) {
+	part, err := getAutoIncrementPart(from, ctx)
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	ctx.Output().Printf("Bumping %s version\n", part)
+	return IncrementAndCreateBranch(part, from, ctx)
+}
+
+func getAutoIncrementPart(from string, ctx interfaces.GitRelContext) (string, error) {
+	releases, err := getReleases(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	head := utils.CoalesceStr(from, "HEAD")
+	since, label := "", "the first commit"
+	if len(releases) > 0 {
+		latest := releases[len(releases)-1]
+		since, label = latest.GetReleasePoint().BranchName, latest.Version.String()
+	}
+
+	commits, err := ctx.Git().ListCommits(since, head)
+	if err != nil {
+		return "", fmt.Errorf("error listing commits since %s: %w", label, err)
+	}
+
+	if len(commits) == 0 {
+		return "", fmt.Errorf("no commits since %s", label)
+	}
+
+	rules := ctx.Command().GetOptBumpTypes()
+	part := conventional.BumpNone
+
+	ctx.Output().Printf("Commits since %s:\n", label)
+	for _, commit := range commits {
+		parsed, ok := conventional.Parse(commit.Subject, commit.Body)
+		if !ok {
+			ctx.Output().Printf(" - %s %s (not a conventional commit)\n", shortSHA(commit.SHA), commit.Subject)
+			continue
+		}
+
+		bump := conventional.Bump(parsed, rules)
+		if parsed.Breaking {
+			ctx.Output().Printf(" - %s %s (%s, breaking change)\n", shortSHA(commit.SHA), commit.Subject, bump)
+		} else {
+			ctx.Output().Printf(" - %s %s (%s)\n", shortSHA(commit.SHA), commit.Subject, bump)
+		}
+
+		part = conventional.HigherBump(part, bump)
+	}
+
+	if part == conventional.BumpNone {
+		return "", fmt.Errorf("none of the commits since %s call for a release (bump types: %s)", label, conventional.FormatBumpRules(rules))
+	}
+
+	return part, nil
+}
+
+// Function to increment to the next prerelease and create a new branch
+func IncrementPrereleaseAndCreateBranch(part string, preid string, from string, ctx interfaces.GitRelContext) (semver.Version, error) {
+	highestVersion, err := getHighestVersion(ctx)
+	if err != nil {
+		return semver.Version{}, err
 	}
 
-	CreateReleaseBranch(newVersion, ctx)
+	newVersion, err := highestVersion.IncrementPrerelease(part, preid)
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	return newVersion, CreateRelease(newVersion, from, ctx)
 }
 
-// Function to list git remotes
-func GetDefaultRemote(gitCtx interfaces.GitContext) (string, error) {
+// Function to choose the remote to use when none is given: the remote the
+// current branch tracks, then the configured remote, then the only remote or
+// origin
+func GetDefaultRemote(configured string, gitCtx interfaces.GitContext) (string, error) {
 	remotes, err := gitCtx.ListRemotes()
 	if err != nil {
 		return "", fmt.Errorf("error listing git remotes: %w", err)
 	}
 
 	if len(remotes) == 0 {
-		return "", fmt.Errorf("no remotes found")
+		return "", newError(ErrNotFound, "no remotes found")
+	}
+
+	// The remote the current branch tracks comes first, so working on a branch
+	// from upstream uses upstream
+	branch, err := gitCtx.GetCurrentBranch()
+	if err == nil && branch != "HEAD" {
+		upstream, err := gitCtx.GetUpstreamRemote(branch)
+		if err != nil {
+			return "", fmt.Errorf("error finding upstream of %s: %w", branch, err)
+		}
+
+		if slices.Contains(remotes, upstream) {
+			return upstream, nil
+		}
+	}
+
+	if configured != "" {
+		return configured, nil
+	}
+
+	if len(remotes) == 1 {
+		return remotes[0], nil
 	}
 
-	if len(remotes) > 1 {
-		return "", fmt.Errorf("multiple remotes found, please specify one")
+	if slices.Contains(remotes, "origin") {
+		return "origin", nil
 	}
 
-	return remotes[0], nil
+	return "", newError(ErrAmbiguousRemote, "multiple remotes found (%s), please specify one with --remote or the remote config", strings.Join(remotes, ", "))
 }
@@ -34,10 +34,10 @@ func TestListReleases_ReturnsListOfReleases(t *testing.T) {
 
 	actualReleases := make([]string, len(releases))
 	for i, release := range releases {
-		actualReleases[i] = release.Version
+		actualReleases[i] = release.Version.String()
 
 		if release.IsLocalOnly() {
-			actualReleases[i] = release.Version + " (local only)"
+			actualReleases[i] = release.Version.String() + " (local only)"
 		}
 	}
 
@@ -77,10 +77,10 @@ func TestListReleases_IncludesLocalOnlyReleases(t *testing.T) {
 
 	actualReleases := make([]string, len(releases))
 	for i, release := range releases {
-		actualReleases[i] = release.Version
+		actualReleases[i] = release.Version.String()
 
 		if release.IsLocalOnly() {
-			actualReleases[i] = release.Version + " (local only)"
+			actualReleases[i] = release.Version.String() + " (local only)"
 		}
 	}
 
@@ -120,10 +120,10 @@ func TestListReleases_ShowRemoteOnlyReleasesButDontMark(t *testing.T) {
 
 	actualReleases := make([]string, len(releases))
 	for i, release := range releases {
-		actualReleases[i] = release.Version
+		actualReleases[i] = release.Version.String()
 
 		if release.IsLocalOnly() {
-			actualReleases[i] = release.Version + " (local only)"
+			actualReleases[i] = release.Version.String() + " (local only)"
 		}
 	}
 
@@ -153,3 +153,227 @@ func TestListReleases_FetchFromRemoteBeforeListing(t *testing.T) {
 		t.Fatalf("expected fetch remote action")
 	}
 }
+
+func TestListReleases_OrdersPrereleasesBySemverPrecedence(t *testing.T) {
+
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.2.3",
+		"release/1.2.3-rc.10",
+		"release/1.2.3-rc.2",
+		"release/1.2.3-beta",
+		"release/1.2.3-alpha",
+		"release/1.2.2",
+	}
+
+	// Act
+	releases, err := ListReleases(ctx)
+	if err != nil {
+		t.Fatalf("error listing releases: %v", err)
+	}
+
+	// Assert
+	expectedReleases := []string{
+		"1.2.2",
+		"1.2.3-alpha",
+		"1.2.3-beta",
+		"1.2.3-rc.2",
+		"1.2.3-rc.10",
+		"1.2.3",
+	}
+
+	actualReleases := make([]string, len(releases))
+	for i, release := range releases {
+		actualReleases[i] = release.Version.String()
+	}
+
+	if !slices.Equal(actualReleases, expectedReleases) {
+		t.Fatalf("expected %v, got %v", expectedReleases, actualReleases)
+	}
+}
+
+func TestListReleases_TagsMode_ReadsReleasesFromTags(t *testing.T) {
+
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Mode = ModeTags
+	ctx.GitContext.Tags = []string{
+		"v1.0.0",
+		"v1.1.0",
+		"v1.1.0-rc.1",
+		"not-a-release",
+		"vnext",
+	}
+
+	// Act
+	releases, err := ListReleases(ctx)
+	if err != nil {
+		t.Fatalf("error listing releases: %v", err)
+	}
+
+	// Assert
+	expectedReleases := []string{
+		"1.0.0",
+		"1.1.0-rc.1",
+		"1.1.0",
+	}
+
+	actualReleases := make([]string, len(releases))
+	for i, release := range releases {
+		actualReleases[i] = release.Version.String()
+
+		if release.IsLocalOnly() {
+			actualReleases[i] = release.Version.String() + " (local only)"
+		}
+	}
+
+	if !slices.Equal(actualReleases, expectedReleases) {
+		t.Fatalf("expected %v, got %v", expectedReleases, actualReleases)
+	}
+}
+
+func TestListReleases_BothMode_MergesBranchesAndTags(t *testing.T) {
+
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Mode = ModeBoth
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.0.0",
+		"release/2.0.0",
+	}
+	ctx.GitContext.Tags = []string{
+		"v2.0.0",
+		"v3.0.0",
+	}
+
+	// Act
+	releases, err := ListReleases(ctx)
+	if err != nil {
+		t.Fatalf("error listing releases: %v", err)
+	}
+
+	// Assert
+	expectedReleases := []string{
+		"1.0.0 (local only)",
+		"2.0.0",
+		"3.0.0",
+	}
+
+	actualReleases := make([]string, len(releases))
+	for i, release := range releases {
+		actualReleases[i] = release.Version.String()
+
+		if release.IsLocalOnly() {
+			actualReleases[i] = release.Version.String() + " (local only)"
+		}
+	}
+
+	if !slices.Equal(actualReleases, expectedReleases) {
+		t.Fatalf("expected %v, got %v", expectedReleases, actualReleases)
+	}
+
+	if len(releases[1].Branches) != 2 {
+		t.Fatalf("expected 2.0.0 to have a branch and a tag, got %v", releases[1].Branches)
+	}
+}
+
+func TestGetDefaultRemote_PrefersUpstreamOfCurrentBranch(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Remotes = []string{"origin", "upstream"}
+	ctx.GitContext.UpstreamRemotes = map[string]string{"main": "upstream"}
+
+	// Act
+	remote, err := GetDefaultRemote("origin", ctx.GitContext)
+
+	// Assert
+	if err != nil || remote != "upstream" {
+		t.Fatalf("expected upstream, got %v (%v)", remote, err)
+	}
+}
+
+func TestGetDefaultRemote_UsesConfiguredRemote(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Remotes = []string{"origin", "upstream"}
+
+	// Act
+	remote, err := GetDefaultRemote("upstream", ctx.GitContext)
+
+	// Assert
+	if err != nil || remote != "upstream" {
+		t.Fatalf("expected upstream, got %v (%v)", remote, err)
+	}
+}
+
+func TestGetDefaultRemote_FallsBackToOrigin(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Remotes = []string{"origin", "upstream"}
+	ctx.GitContext.UpstreamRemotes = map[string]string{"feature": "upstream"}
+
+	// Act
+	remote, err := GetDefaultRemote("", ctx.GitContext)
+
+	// Assert
+	if err != nil || remote != "origin" {
+		t.Fatalf("expected origin, got %v (%v)", remote, err)
+	}
+}
+
+func TestGetDefaultRemote_UsesOnlyRemote(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Remotes = []string{"fork"}
+
+	// Act
+	remote, err := GetDefaultRemote("", ctx.GitContext)
+
+	// Assert
+	if err != nil || remote != "fork" {
+		t.Fatalf("expected fork, got %v (%v)", remote, err)
+	}
+}
+
+func TestListReleases_AllRemotes_MergesReleasesFromEveryRemote(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.AllRemotes = true
+	ctx.GitContext.Remotes = []string{"origin", "upstream"}
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.0.0",
+		"remotes/origin/release/1.0.0",
+		"remotes/upstream/release/1.0.0",
+		"remotes/upstream/release/2.0.0",
+	}
+
+	// Act
+	releases, err := ListReleases(ctx)
+	if err != nil {
+		t.Fatalf("error listing releases: %v", err)
+	}
+
+	// Assert
+	expected := map[string][]string{
+		"1.0.0": {"local:", "remote:origin", "remote:upstream"},
+		"2.0.0": {"remote:upstream"},
+	}
+	if len(releases) != len(expected) {
+		t.Fatalf("expected %d releases, got %d", len(expected), len(releases))
+	}
+
+	for _, release := range releases {
+		branches := []string{}
+		for _, branch := range release.Branches {
+			branches = append(branches, branch.Type+":"+branch.Remote)
+		}
+
+		if !slices.Equal(branches, expected[release.Version.String()]) {
+			t.Errorf("expected branches %v for %v, got %v", expected[release.Version.String()], release.Version, branches)
+		}
+	}
+}
@@ -1,8 +1,18 @@
 package git
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gitrel/interfaces"
+	"io/fs"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type CmdGitContext struct{}
@@ -25,32 +35,124 @@ func (c *CmdGitContext) FetchRemote(remote string) error {
 	return err
 }
 
-func (c *CmdGitContext) ListAllBranches() ([]string, error) {
-	output, err := _execCommand("git", "branch", "-a")
+// refFormat is the for-each-ref format for a branch: its name, symbolic ref
+// target, tip commit, commit date, upstream and upstream tracking, separated by
+// NUL bytes
+const refFormat = "%(refname)%00%(symref)%00%(objectname)%00%(committerdate:iso-strict)%00%(upstream:short)%00%(upstream:track,nobracket)"
+
+func (c *CmdGitContext) ListAllBranches() ([]interfaces.Ref, error) {
+	output, err := _execCommand("git", "for-each-ref", "--format="+refFormat, "refs/heads", "refs/remotes")
 	if err != nil {
 		return nil, err
 	}
 
-	rawBranches := strings.Split(output, "\n")
-	branches := make([]string, 0, len(rawBranches))
-	for _, branch := range rawBranches {
-		branch = strings.TrimPrefix(branch, "*")
-		
-		if branch != "" {
-			branches = append(branches, strings.TrimSpace(branch))
+	refs := []interfaces.Ref{}
+	for _, line := range splitLines(output) {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 6 {
+			return nil, fmt.Errorf("unexpected for-each-ref output: %q", line)
+		}
+
+		// Skip symbolic refs such as refs/remotes/origin/HEAD
+		if fields[1] != "" {
+			continue
+		}
+
+		ref := interfaces.Ref{
+			Name:      fields[0],
+			ShortName: interfaces.ShortRefName(fields[0]),
+			SHA:       fields[2],
+			Upstream:  fields[4],
 		}
+
+		ref.Date, err = time.Parse(time.RFC3339, fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("error parsing date of %s: %w", ref.Name, err)
+		}
+
+		ref.Ahead, ref.Behind = parseTrack(fields[5])
+		refs = append(refs, ref)
 	}
 
-	return branches, nil
+	return refs, nil
 }
 
-func (c *CmdGitContext) BranchExists(branchName string) (bool, error) {
-	output, err := _execCommand("git", "branch", "--list", branchName)
+// ListRemoteRefs asks the remote for its branches and tags without fetching.
+// Branches are named as their remote tracking branches would be, and annotated
+// tags have the SHA of the commit they tag.
+func (c *CmdGitContext) ListRemoteRefs(remote string) ([]interfaces.Ref, error) {
+	output, err := _execCommand("git", "ls-remote", "--heads", "--tags", remote)
 	if err != nil {
-		return false, err
+		return nil, commandError(err, output)
+	}
+
+	refs := []interfaces.Ref{}
+	peeled := map[string]string{}
+	for _, line := range splitLines(output) {
+		sha, name, ok := strings.Cut(line, "\t")
+		if !ok {
+			return nil, fmt.Errorf("unexpected ls-remote output: %q", line)
+		}
+
+		if tag, ok := strings.CutSuffix(name, "^{}"); ok {
+			peeled[tag] = sha
+			continue
+		}
+
+		refs = append(refs, interfaces.Ref{Name: name, ShortName: remoteShortName(name, remote), SHA: sha})
+	}
+
+	for i, ref := range refs {
+		if sha, ok := peeled[ref.Name]; ok {
+			refs[i].SHA = sha
+		}
+	}
+
+	return refs, nil
+}
+
+// remoteShortName returns the name of a branch or tag on a remote as it would
+// be once fetched, e.g. remotes/origin/main or v1.0.0
+func remoteShortName(name string, remote string) string {
+	if branch, ok := strings.CutPrefix(name, "refs/heads/"); ok {
+		return "remotes/" + remote + "/" + branch
+	}
+
+	return strings.TrimPrefix(name, "refs/tags/")
+}
+
+// parseTrack parses the ahead and behind counts from %(upstream:track), such
+// as "ahead 1, behind 2". An upstream that is gone or in sync has neither.
+func parseTrack(track string) (int, int) {
+	ahead, behind := 0, 0
+	for _, part := range strings.Split(track, ", ") {
+		if count, ok := strings.CutPrefix(part, "ahead "); ok {
+			ahead, _ = strconv.Atoi(count)
+		} else if count, ok := strings.CutPrefix(part, "behind "); ok {
+			behind, _ = strconv.Atoi(count)
+		}
+	}
+
+	return ahead, behind
+}
+
+// BranchExists checks for a local branch, or a remote tracking branch when
+// given as remotes/<remote>/<branch>
+func (c *CmdGitContext) BranchExists(branchName string) (bool, error) {
+	refName := "refs/heads/" + branchName
+	if strings.HasPrefix(branchName, "remotes/") {
+		refName = "refs/" + branchName
+	}
+
+	_, err := _execCommand("git", "show-ref", "--verify", "--quiet", refName)
+
+	// show-ref exits with 1 when the ref doesn't exist
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
 	}
 
-	return strings.Contains(output, branchName), nil
+	return err == nil, err
 }
 
 func (c *CmdGitContext) CheckoutBranch(branchName string) error {
@@ -69,8 +171,12 @@ func (c *CmdGitContext) SwitchBack() error {
 }
 
 func (c *CmdGitContext) PushBranch(remote string, branchSpec string) error {
-	_, err := _execCommand("git", "push", remote, branchSpec)
-	return err
+	output, err := _execCommand("git", "push", remote, branchSpec)
+	if err != nil {
+		return commandError(err, output)
+	}
+
+	return nil
 }
 
 func (c *CmdGitContext) GetCurrentBranch() (string, error) {
@@ -87,6 +193,11 @@ func (c *CmdGitContext) CreateBranchAt(branchName string, commitish string) erro
 	return err
 }
 
+func (c *CmdGitContext) DeleteBranch(branchName string) error {
+	_, err := _execCommand("git", "branch", "-D", branchName)
+	return err
+}
+
 func (c *CmdGitContext) ListRemotes() ([]string, error) {
 	output, err := _execCommand("git", "remote")
 	if err != nil {
@@ -101,14 +212,504 @@ func (c *CmdGitContext) ListRemotes() ([]string, error) {
 		}
 	}
 
+	slices.Sort(remotes)
 	return remotes, nil
 }
 
+// GetUpstreamRemote returns the remote a branch tracks, or an empty string if
+// it doesn't track a remote branch
+func (c *CmdGitContext) GetUpstreamRemote(branchName string) (string, error) {
+	output, err := _execCommand("git", "config", "--get", "branch."+branchName+".remote")
+
+	// git config exits with 1 when the key isn't set
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	// A remote of . tracks another local branch
+	remote := strings.TrimSpace(output)
+	if remote == "." {
+		return "", nil
+	}
+
+	return remote, nil
+}
+
 func (c *CmdGitContext) MergeBranch(branchName string) error {
-	_, err := _execCommand("git", "merge", branchName)
+	output, err := _execCommand("git", "merge", branchName)
+	if err != nil {
+		return commandError(err, output)
+	}
+
+	return nil
+}
+
+func (c *CmdGitContext) ContinueMerge() error {
+	// Keep the default merge message rather than opening an editor
+	output, err := _execCommand("git", "-c", "core.editor=true", "merge", "--continue")
+	if err != nil {
+		return commandError(err, output)
+	}
+
+	return nil
+}
+
+func (c *CmdGitContext) AbortMerge() error {
+	_, err := _execCommand("git", "merge", "--abort")
+	return err
+}
+
+func (c *CmdGitContext) GetDefaultBranch(remote string) (string, error) {
+	output, err := _execCommand("git", "symbolic-ref", "--short", "refs/remotes/"+remote+"/HEAD")
+	if err != nil {
+		return "", fmt.Errorf("could not determine the default branch of %s. try running 'git remote set-head %s --auto'", remote, remote)
+	}
+
+	return strings.TrimPrefix(strings.TrimSpace(output), remote+"/"), nil
+}
+
+func (c *CmdGitContext) ListTags() ([]string, error) {
+	output, err := _execCommand("git", "tag", "--list")
+	if err != nil {
+		return nil, err
+	}
+
+	return splitLines(output), nil
+}
+
+func (c *CmdGitContext) ListTagsAt(commitish string) ([]string, error) {
+	output, err := _execCommand("git", "tag", "--points-at", commitish)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitLines(output), nil
+}
+
+func (c *CmdGitContext) CreateTag(tagName string, commitish string, message string) error {
+	args := []string{"tag", "-a", tagName, "-m", message}
+	if commitish != "" {
+		args = append(args, commitish)
+	}
+
+	_, err := _execCommand("git", args...)
+	return err
+}
+
+func (c *CmdGitContext) PushTag(remote string, tagName string) error {
+	output, err := _execCommand("git", "push", remote, "refs/tags/"+tagName)
+	if err != nil {
+		return commandError(err, output)
+	}
+
+	return nil
+}
+
+func (c *CmdGitContext) DeleteTag(tagName string) error {
+	_, err := _execCommand("git", "tag", "-d", tagName)
+	return err
+}
+
+func (c *CmdGitContext) DeleteRemoteRef(remote string, ref string) error {
+	_, err := _execCommand("git", "push", remote, "--delete", ref)
+	return err
+}
+
+func (c *CmdGitContext) ForcePushBranch(remote string, branchSpec string) error {
+	output, err := _execCommand("git", "push", "--force-with-lease", remote, branchSpec)
+	if err != nil {
+		return commandError(err, output)
+	}
+
+	return nil
+}
+
+// ResetBranch moves the current branch to a commit, discarding changes in the
+// working tree
+func (c *CmdGitContext) ResetBranch(commit string) error {
+	_, err := _execCommand("git", "reset", "--hard", commit)
 	return err
 }
 
+func (c *CmdGitContext) ResolveCommit(commitish string) (string, error) {
+	output, err := _execCommand("git", "rev-parse", "--verify", "--quiet", commitish+"^{commit}")
+	if err != nil {
+		return "", newError(ErrNotFound, "not a valid commit: %s", commitish)
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// IsAncestor checks whether a commit is reachable from another, which
+// includes the commit itself
+func (c *CmdGitContext) IsAncestor(ancestor string, commitish string) (bool, error) {
+	output, err := _execCommand("git", "merge-base", "--is-ancestor", ancestor, commitish)
+
+	// git merge-base exits with 1 when it isn't an ancestor
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+
+	// git names the missing commit last, e.g. Not a valid object name missing
+	if err != nil && isUnknownRevision(output) {
+		fields := strings.Fields(output)
+		return false, newError(ErrNotFound, "not a valid commit: %s", fields[len(fields)-1])
+	}
+
+	if err != nil {
+		return false, commandError(err, output)
+	}
+
+	return true, nil
+}
+
+// isUnknownRevision checks whether git failed because a commit-ish doesn't
+// exist
+func isUnknownRevision(output string) bool {
+	return strings.Contains(output, "Not a valid object name") ||
+		strings.Contains(output, "Not a valid commit name") ||
+		strings.Contains(output, "unknown revision")
+}
+
+// CountCommits counts the commits reachable from to but not from from, or
+// every commit reachable from to if from is empty
+func (c *CmdGitContext) CountCommits(from string, to string) (int, error) {
+	revisions := to
+	if from != "" {
+		revisions = from + ".." + to
+	}
+
+	output, err := _execCommand("git", "rev-list", "--count", revisions)
+	if err != nil {
+		return 0, commandError(err, output)
+	}
+
+	return strconv.Atoi(strings.TrimSpace(output))
+}
+
+// commitFormat is the git log format for a commit: its SHA, author name,
+// email and date, subject and body, separated by NUL bytes and ending with a
+// record separator
+const commitFormat = "%H%x00%an%x00%ae%x00%aI%x00%s%x00%b%x1e"
+
+// ListCommits lists the commits reachable from to but not from from, or every
+// commit reachable from to if from is empty, newest first
+func (c *CmdGitContext) ListCommits(from string, to string) ([]interfaces.Commit, error) {
+	revisions := to
+	if from != "" {
+		revisions = from + ".." + to
+	}
+
+	output, err := _execCommand("git", "log", "--format="+commitFormat, revisions, "--")
+	if err != nil {
+		return nil, commandError(err, output)
+	}
+
+	commits := []interfaces.Commit{}
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+
+		fields := strings.Split(record, "\x00")
+		if len(fields) != 6 {
+			return nil, fmt.Errorf("unexpected git log output: %q", record)
+		}
+
+		commit := interfaces.Commit{
+			SHA:         fields[0],
+			Author:      fields[1],
+			AuthorEmail: fields[2],
+			Subject:     fields[4],
+			Body:        strings.TrimSpace(fields[5]),
+		}
+
+		commit.Date, err = time.Parse(time.RFC3339, fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("error parsing date of %s: %w", commit.SHA, err)
+		}
+
+		commits = append(commits, commit)
+	}
+
+	return commits, nil
+}
+
+// Diff returns the changes from one commit to another as a patch
+func (c *CmdGitContext) Diff(from string, to string) (string, error) {
+	output, err := _execCommand("git", "diff", "--no-color", "--no-ext-diff", from, to, "--")
+	if err != nil {
+		return "", commandError(err, output)
+	}
+
+	return output, nil
+}
+
+// DiffStat counts the lines added and deleted in each file changed from one
+// commit to another, following renames
+func (c *CmdGitContext) DiffStat(from string, to string) ([]interfaces.FileStat, error) {
+	output, err := _execCommand("git", "diff", "--numstat", "-z", "--find-renames", from, to, "--")
+	if err != nil {
+		return nil, commandError(err, output)
+	}
+
+	// Each file is added, deleted and path separated by tabs and ending with a
+	// NUL byte, except that a rename has an empty path followed by the old and
+	// new paths as two more NUL-terminated fields
+	stats := []interfaces.FileStat{}
+	fields := strings.Split(output, "\x00")
+	for i := 0; i < len(fields); i++ {
+		if fields[i] == "" {
+			continue
+		}
+
+		counts := strings.SplitN(fields[i], "\t", 3)
+		if len(counts) != 3 {
+			return nil, fmt.Errorf("unexpected git diff output: %q", fields[i])
+		}
+
+		stat := interfaces.FileStat{Path: counts[2]}
+		if stat.Path == "" {
+			if i+2 >= len(fields) {
+				return nil, fmt.Errorf("unexpected git diff output: %q", output)
+			}
+
+			stat.OldPath, stat.Path = fields[i+1], fields[i+2]
+			i += 2
+		}
+
+		if counts[0] == "-" {
+			stat.Binary = true
+		} else {
+			stat.Additions, _ = strconv.Atoi(counts[0])
+			stat.Deletions, _ = strconv.Atoi(counts[1])
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+// RefDate returns when a ref was made: the tagger date of an annotated tag, or
+// else the committer date of the commit it points at
+func (c *CmdGitContext) RefDate(refName string) (time.Time, error) {
+	output, err := _execCommand("git", "for-each-ref", "--format=%(refname) %(creatordate:iso-strict)", refName)
+	if err != nil {
+		return time.Time{}, commandError(err, output)
+	}
+
+	// The name is a prefix, so refs below it are listed too
+	for _, line := range strings.Split(output, "\n") {
+		name, date, _ := strings.Cut(strings.TrimSpace(line), " ")
+		if name == refName {
+			return time.Parse(time.RFC3339, date)
+		}
+	}
+
+	return time.Time{}, newError(ErrNotFound, "ref %s not found", refName)
+}
+
+// ReadFile returns the contents of a file, relative to the top of the
+// repository, at a commit
+func (c *CmdGitContext) ReadFile(commitish string, path string) ([]byte, error) {
+	output, err := _execCommand("git", "cat-file", "blob", commitish+":"+path)
+	if err != nil {
+		return nil, newError(ErrNotFound, "%s not found at %s", path, commitish)
+	}
+
+	return []byte(output), nil
+}
+
+// CommitFiles commits new contents for files onto the tip of a branch without
+// checking it out, by building the tree in a temporary index. The branch must
+// not be the current branch, whose working tree would be left behind.
+func (c *CmdGitContext) CommitFiles(branchName string, files map[string][]byte, message string) error {
+	current, err := c.GetCurrentBranch()
+	if err != nil {
+		return err
+	}
+
+	if current == branchName {
+		return fmt.Errorf("cannot commit files to the current branch %s", branchName)
+	}
+
+	parent, err := c.ResolveCommit("refs/heads/" + branchName)
+	if err != nil {
+		return err
+	}
+
+	indexDir, err := os.MkdirTemp("", "gitrel-index-")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(indexDir)
+	index := []string{"GIT_INDEX_FILE=" + filepath.Join(indexDir, "index")}
+
+	output, err := _execGit(index, nil, "read-tree", parent)
+	if err != nil {
+		return commandError(err, output)
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+
+	slices.Sort(paths)
+	for _, path := range paths {
+		output, err = _execGit(nil, files[path], "hash-object", "-w", "--stdin")
+		if err != nil {
+			return commandError(err, output)
+		}
+
+		blob := strings.TrimSpace(output)
+
+		// Keep the mode of files that are already there, e.g. executables
+		mode := "100644"
+		output, err = _execGit(index, nil, "ls-files", "--stage", "--", path)
+		if err != nil {
+			return commandError(err, output)
+		}
+
+		if fields := strings.Fields(output); len(fields) > 0 {
+			mode = fields[0]
+		}
+
+		output, err = _execGit(index, nil, "update-index", "--add", "--cacheinfo", mode+","+blob+","+path)
+		if err != nil {
+			return commandError(err, output)
+		}
+	}
+
+	output, err = _execGit(index, nil, "write-tree")
+	if err != nil {
+		return commandError(err, output)
+	}
+
+	output, err = _execCommand("git", "commit-tree", strings.TrimSpace(output), "-p", parent, "-m", message)
+	if err != nil {
+		return commandError(err, output)
+	}
+
+	output, err = _execCommand("git", "update-ref", "refs/heads/"+branchName, strings.TrimSpace(output), parent)
+	if err != nil {
+		return commandError(err, output)
+	}
+
+	return nil
+}
+
+func (c *CmdGitContext) CherryPick(commit string) error {
+	output, err := _execCommand("git", "cherry-pick", "-x", commit)
+	if err != nil {
+		return commandError(err, output)
+	}
+
+	return nil
+}
+
+func (c *CmdGitContext) ContinueCherryPick() error {
+	// Keep the message of the picked commit rather than opening an editor
+	output, err := _execCommand("git", "-c", "core.editor=true", "cherry-pick", "--continue")
+	if err != nil {
+		return commandError(err, output)
+	}
+
+	return nil
+}
+
+func (c *CmdGitContext) AbortCherryPick() error {
+	_, err := _execCommand("git", "cherry-pick", "--abort")
+	return err
+}
+
+// ReadState returns the contents of a gitrel state file, or nil if there is
+// none. State files live in the gitrel directory inside .git.
+func (c *CmdGitContext) ReadState(name string) ([]byte, error) {
+	path, err := statePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	return data, err
+}
+
+func (c *CmdGitContext) WriteState(name string, data []byte) error {
+	path, err := statePath(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (c *CmdGitContext) RemoveState(name string) error {
+	path, err := statePath(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// Function to get the path of a state file in the gitrel directory of .git
+func statePath(name string) (string, error) {
+	output, err := _execCommand("git", "rev-parse", "--git-path", "gitrel")
+	if err != nil {
+		return "", fmt.Errorf("error finding .git directory: %w", err)
+	}
+
+	return filepath.Join(strings.TrimSpace(output), name), nil
+}
+
+// Function to split command output into trimmed, non-empty lines
+func splitLines(output string) []string {
+	rawLines := strings.Split(output, "\n")
+	lines := make([]string, 0, len(rawLines))
+	for _, line := range rawLines {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// Function to execute git with extra environment variables and standard input
+// and return its output
+func _execGit(env []string, input []byte, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = bytes.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	return string(output), err
+}
+
 // Function to execute a shell command and return its output
 func _execCommand(command string, args ...string) (string, error) {
 	cmd := exec.Command(command, args...)
@@ -0,0 +1,498 @@
+package git
+
+import (
+	"errors"
+	"gitrel/interfaces"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Every GitContext backend must pass the same contract, run against a real
+// repository with an origin remote. Each case gets a fresh repository, with
+// main committed and pushed to origin.
+var gitContextContract = map[string]func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext){
+	"GetCurrentBranch": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		assertEqual(t, "main", must(gitCtx.GetCurrentBranch()))
+
+		repo.git(t, "checkout", "--detach")
+		assertEqual(t, "HEAD", must(gitCtx.GetCurrentBranch()))
+	},
+	"ListAllBranches": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.git(t, "branch", "release/1.0.0")
+
+		assertEqual(t, "main,release/1.0.0,remotes/origin/main", branchNames(must(gitCtx.ListAllBranches())))
+	},
+	"ListAllBranches_Metadata": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.git(t, "branch", "--set-upstream-to=origin/main")
+		repo.commit(t, "local")
+		remote := repo.git(t, "commit-tree", "-p", "origin/main", "-m", "remote", "origin/main^{tree}")
+		repo.git(t, "update-ref", "refs/remotes/origin/main", remote)
+
+		branches := must(gitCtx.ListAllBranches())
+
+		main := findRef(t, branches, "main")
+		assertEqual(t, "refs/heads/main", main.Name)
+		assertEqual(t, repo.git(t, "rev-parse", "main"), main.SHA)
+		assertEqual(t, repo.git(t, "log", "-1", "--format=%ct", "main"), strconv.FormatInt(main.Date.Unix(), 10))
+		assertEqual(t, "origin/main", main.Upstream)
+		assertEqual(t, 1, main.Ahead)
+		assertEqual(t, 1, main.Behind)
+
+		originMain := findRef(t, branches, "remotes/origin/main")
+		assertEqual(t, "refs/remotes/origin/main", originMain.Name)
+		assertEqual(t, remote, originMain.SHA)
+		assertEqual(t, "", originMain.Upstream)
+	},
+	"ListAllBranches_DetachedHead": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.git(t, "checkout", "--detach")
+
+		assertEqual(t, "main,remotes/origin/main", branchNames(must(gitCtx.ListAllBranches())))
+	},
+	"BranchExists": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		assertEqual(t, true, must(gitCtx.BranchExists("main")))
+		assertEqual(t, false, must(gitCtx.BranchExists("release/1.0.0")))
+		assertEqual(t, true, must(gitCtx.BranchExists("remotes/origin/main")))
+		assertEqual(t, false, must(gitCtx.BranchExists("remotes/origin/release/1.0.0")))
+	},
+	"BranchExists_ExactMatch": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.git(t, "branch", "release/1.0.10")
+
+		assertEqual(t, false, must(gitCtx.BranchExists("release/1.0.1")))
+		assertEqual(t, false, must(gitCtx.BranchExists("release/1.0")))
+		assertEqual(t, true, must(gitCtx.BranchExists("release/1.0.10")))
+	},
+	"CreateBranchAt": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		head := repo.git(t, "rev-parse", "HEAD")
+		repo.commit(t, "second")
+
+		assertNoError(t, gitCtx.CreateBranchAt("release/1.0.0", head))
+
+		assertEqual(t, head, must(gitCtx.ResolveCommit("release/1.0.0")))
+		assertEqual(t, "main", must(gitCtx.GetCurrentBranch()))
+		assertError(t, gitCtx.CreateBranchAt("release/1.0.0", "main"))
+	},
+	"CreateBranchAt_TracksRemoteBranch": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		assertNoError(t, gitCtx.CreateBranchAt("local", "remotes/origin/main"))
+
+		assertEqual(t, "origin", repo.git(t, "config", "branch.local.remote"))
+		assertEqual(t, "refs/heads/main", repo.git(t, "config", "branch.local.merge"))
+	},
+	"DeleteBranch": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.git(t, "branch", "release/1.0.0")
+
+		assertNoError(t, gitCtx.DeleteBranch("release/1.0.0"))
+
+		assertEqual(t, false, must(gitCtx.BranchExists("release/1.0.0")))
+		assertError(t, gitCtx.DeleteBranch("release/1.0.0"))
+		assertError(t, gitCtx.DeleteBranch("main"))
+	},
+	"ListRemoteRefs": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		head := repo.git(t, "rev-parse", "HEAD")
+		repo.git(t, "tag", "-a", "v1.0.0", "-m", "Release 1.0.0")
+		// Pushing by path leaves the remote tracking branches as they were
+		repo.git(t, "push", filepath.Join(repo.dir, "..", "origin.git"), "main:refs/heads/release/1.0.0", "v1.0.0")
+		repo.git(t, "branch", "release/2.0.0")
+
+		refs := must(gitCtx.ListRemoteRefs("origin"))
+
+		assertEqual(t, "remotes/origin/main,remotes/origin/release/1.0.0,v1.0.0", branchNames(refs))
+		assertEqual(t, "refs/tags/v1.0.0", findRef(t, refs, "v1.0.0").Name)
+		assertEqual(t, head, findRef(t, refs, "v1.0.0").SHA)
+		assertEqual(t, head, findRef(t, refs, "remotes/origin/release/1.0.0").SHA)
+		assertEqual(t, false, must(gitCtx.BranchExists("remotes/origin/release/1.0.0")))
+	},
+	"SwitchToNewBranch": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		assertNoError(t, gitCtx.SwitchToNewBranch("release/1.0.0"))
+		assertEqual(t, "release/1.0.0", must(gitCtx.GetCurrentBranch()))
+
+		assertNoError(t, gitCtx.SwitchBack())
+		assertEqual(t, "main", must(gitCtx.GetCurrentBranch()))
+	},
+	"PushBranch": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.git(t, "branch", "release/1.0.0")
+
+		assertNoError(t, gitCtx.PushBranch("origin", "release/1.0.0:release/1.0.0"))
+
+		assertEqual(t, true, must(gitCtx.BranchExists("remotes/origin/release/1.0.0")))
+		assertEqual(t, must(gitCtx.ResolveCommit("main")), must(gitCtx.ResolveCommit("refs/remotes/origin/release/1.0.0")))
+	},
+	"MergeBranch_Conflict": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.git(t, "switch", "-c", "release/1.0.0")
+		repo.writeFile(t, "file.txt", "release")
+		repo.commit(t, "release change")
+		repo.git(t, "switch", "main")
+		repo.writeFile(t, "file.txt", "main")
+		repo.commit(t, "main change")
+		repo.git(t, "switch", "release/1.0.0")
+
+		err := gitCtx.MergeBranch("main")
+
+		assertErrorIs(t, err, ErrMergeConflict)
+		assertNoError(t, gitCtx.AbortMerge())
+	},
+	"ListRemotes": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		assertEqual(t, "origin", strings.Join(must(gitCtx.ListRemotes()), ","))
+
+		repo.git(t, "remote", "add", "upstream", "../upstream.git")
+		repo.git(t, "remote", "add", "backup", "../backup.git")
+		assertEqual(t, "backup,origin,upstream", strings.Join(must(gitCtx.ListRemotes()), ","))
+	},
+	"GetUpstreamRemote": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		assertEqual(t, "", must(gitCtx.GetUpstreamRemote("main")))
+
+		repo.git(t, "branch", "--set-upstream-to=origin/main")
+		assertEqual(t, "origin", must(gitCtx.GetUpstreamRemote("main")))
+
+		repo.git(t, "branch", "--track", "local", "main")
+		assertEqual(t, "", must(gitCtx.GetUpstreamRemote("local")))
+	},
+	"GetDefaultBranch": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		assertEqual(t, "main", must(gitCtx.GetDefaultBranch("origin")))
+
+		_, err := gitCtx.GetDefaultBranch("upstream")
+		assertError(t, err)
+	},
+	"HasUncommittedChanges": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		assertEqual(t, false, must(gitCtx.HasUncommittedChanges()))
+
+		repo.writeFile(t, "file.txt", "changed")
+		assertEqual(t, true, must(gitCtx.HasUncommittedChanges()))
+	},
+	"Tags": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		first := repo.git(t, "rev-parse", "HEAD")
+		repo.commit(t, "second")
+
+		assertNoError(t, gitCtx.CreateTag("v1.1.0", "", "Release 1.1.0"))
+		assertNoError(t, gitCtx.CreateTag("v1.0.0", first, "Release 1.0.0"))
+		repo.git(t, "tag", "v1.1.0-light")
+
+		assertEqual(t, "v1.0.0,v1.1.0,v1.1.0-light", strings.Join(must(gitCtx.ListTags()), ","))
+		assertEqual(t, "v1.1.0,v1.1.0-light", strings.Join(must(gitCtx.ListTagsAt("HEAD")), ","))
+		assertEqual(t, first, must(gitCtx.ResolveCommit("v1.0.0")))
+		assertEqual(t, "tag", repo.git(t, "cat-file", "-t", "v1.0.0"))
+		assertEqual(t, "Release 1.0.0", repo.git(t, "tag", "-l", "--format=%(contents:subject)", "v1.0.0"))
+		assertError(t, gitCtx.CreateTag("v1.0.0", "", "Release 1.0.0"))
+
+		assertNoError(t, gitCtx.DeleteTag("v1.0.0"))
+		assertEqual(t, "v1.1.0,v1.1.0-light", strings.Join(must(gitCtx.ListTags()), ","))
+		assertError(t, gitCtx.DeleteTag("v1.0.0"))
+	},
+	"ResolveCommit": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		head := repo.git(t, "rev-parse", "HEAD")
+
+		assertEqual(t, head, must(gitCtx.ResolveCommit("HEAD")))
+		assertEqual(t, head, must(gitCtx.ResolveCommit("main")))
+		assertEqual(t, head, must(gitCtx.ResolveCommit("origin/main")))
+		assertEqual(t, head, must(gitCtx.ResolveCommit(head[:7])))
+
+		_, err := gitCtx.ResolveCommit("missing")
+		assertErrorIs(t, err, ErrNotFound)
+	},
+	"IsAncestor": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.git(t, "branch", "release/1.0.0")
+		repo.commit(t, "second")
+
+		assertEqual(t, true, must(gitCtx.IsAncestor("release/1.0.0", "main")))
+		assertEqual(t, true, must(gitCtx.IsAncestor("main", "main")))
+		assertEqual(t, false, must(gitCtx.IsAncestor("main", "release/1.0.0")))
+
+		_, err := gitCtx.IsAncestor("missing", "main")
+		assertErrorIs(t, err, ErrNotFound)
+
+		_, err = gitCtx.IsAncestor("main", "missing")
+		assertErrorIs(t, err, ErrNotFound)
+	},
+	"CountCommits": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.git(t, "branch", "release/1.0.0")
+		repo.commit(t, "second")
+		repo.commit(t, "third")
+
+		assertEqual(t, 2, must(gitCtx.CountCommits("release/1.0.0", "main")))
+		assertEqual(t, 0, must(gitCtx.CountCommits("main", "release/1.0.0")))
+		assertEqual(t, 3, must(gitCtx.CountCommits("", "main")))
+	},
+	"CountCommits_AcrossMerges": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.commit(t, "base")
+		repo.git(t, "branch", "release/1.0.0")
+		repo.commit(t, "main change")
+		repo.git(t, "switch", "release/1.0.0")
+		repo.commit(t, "release change")
+		repo.git(t, "switch", "main")
+		repo.git(t, "merge", "--no-edit", "release/1.0.0")
+		repo.commit(t, "after merge")
+
+		assertEqual(t, 3, must(gitCtx.CountCommits("release/1.0.0", "main")))
+		assertEqual(t, 0, must(gitCtx.CountCommits("main", "release/1.0.0")))
+		assertEqual(t, 1, must(gitCtx.CountCommits("main~2", "release/1.0.0")))
+		assertEqual(t, true, must(gitCtx.IsAncestor("release/1.0.0", "main")))
+		assertEqual(t, false, must(gitCtx.IsAncestor("main~2", "release/1.0.0")))
+		assertEqual(t, 3, len(must(gitCtx.ListCommits("release/1.0.0", "main"))))
+	},
+	"ListCommits": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.git(t, "branch", "release/1.0.0")
+		repo.commit(t, "feat: second\n\nWith a body\n\nBREAKING CHANGE: it breaks")
+		repo.commit(t, "fix: third")
+
+		commits := must(gitCtx.ListCommits("release/1.0.0", "main"))
+
+		assertEqual(t, 2, len(commits))
+		assertEqual(t, repo.git(t, "rev-parse", "main"), commits[0].SHA)
+		assertEqual(t, "fix: third", commits[0].Subject)
+		assertEqual(t, "", commits[0].Body)
+		assertEqual(t, repo.git(t, "log", "-1", "--format=%an <%ae>", "main"), commits[0].Author+" <"+commits[0].AuthorEmail+">")
+		assertEqual(t, repo.git(t, "log", "-1", "--format=%at", "main"), strconv.FormatInt(commits[0].Date.Unix(), 10))
+		assertEqual(t, "feat: second", commits[1].Subject)
+		assertEqual(t, "With a body\n\nBREAKING CHANGE: it breaks", commits[1].Body)
+		assertEqual(t, 3, len(must(gitCtx.ListCommits("", "main"))))
+		assertEqual(t, 0, len(must(gitCtx.ListCommits("main", "release/1.0.0"))))
+	},
+	"Diff": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.writeFile(t, "VERSION", "1.0.0\n")
+		repo.commit(t, "version")
+		repo.git(t, "branch", "release/1.0.0")
+		repo.writeFile(t, "VERSION", "1.1.0\n")
+		repo.commit(t, "bump")
+
+		diff := must(gitCtx.Diff("release/1.0.0", "main"))
+
+		assertEqual(t, repo.git(t, "diff", "release/1.0.0", "main")+"\n", diff)
+		assertEqual(t, true, strings.Contains(diff, "-1.0.0\n+1.1.0\n"))
+		assertEqual(t, "", must(gitCtx.Diff("main", "main")))
+	},
+	"DiffStat": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.writeFile(t, "README.md", "one\ntwo\nthree\nfour\nfive\n")
+		repo.writeFile(t, "VERSION", "1.0.0\n")
+		repo.commit(t, "files")
+		repo.git(t, "branch", "release/1.0.0")
+		repo.git(t, "mv", "README.md", "README")
+		repo.writeFile(t, "README", "one\ntwo\nthree\nfour\nfive\nsix\n")
+		repo.writeFile(t, "VERSION", "1.1.0\n")
+		repo.writeFile(t, "logo.png", "\x89PNG\x00\x01")
+		repo.commit(t, "changes")
+
+		stats := must(gitCtx.DiffStat("release/1.0.0", "main"))
+
+		assertEqual(t, 3, len(stats))
+		if len(stats) == 3 {
+			assertEqual(t, interfaces.FileStat{Path: "README", OldPath: "README.md", Additions: 1}, stats[0])
+			assertEqual(t, interfaces.FileStat{Path: "VERSION", Additions: 1, Deletions: 1}, stats[1])
+			assertEqual(t, interfaces.FileStat{Path: "logo.png", Binary: true}, stats[2])
+		}
+
+		assertEqual(t, 0, len(must(gitCtx.DiffStat("main", "main"))))
+	},
+	"RefDate": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.git(t, "tag", "-a", "v1.0.0", "-m", "Release 1.0.0")
+
+		branchDate := must(gitCtx.RefDate("refs/heads/main"))
+		tagDate := must(gitCtx.RefDate("refs/tags/v1.0.0"))
+
+		assertEqual(t, repo.git(t, "log", "-1", "--format=%ct", "main"), strconv.FormatInt(branchDate.Unix(), 10))
+		assertEqual(t, repo.git(t, "for-each-ref", "--format=%(taggerdate:unix)", "refs/tags/v1.0.0"), strconv.FormatInt(tagDate.Unix(), 10))
+		_, err := gitCtx.RefDate("refs/heads/mai")
+		assertErrorIs(t, err, interfaces.ErrNotFound)
+	},
+	"ReadFile": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		repo.git(t, "branch", "release/1.0.0")
+		os.Mkdir(filepath.Join(repo.dir, "chart"), 0o755)
+		repo.writeFile(t, "chart/Chart.yaml", "version: 1.1.0\n")
+		repo.commit(t, "chart")
+
+		assertEqual(t, "version: 1.1.0\n", string(must(gitCtx.ReadFile("main", "chart/Chart.yaml"))))
+
+		_, err := gitCtx.ReadFile("release/1.0.0", "chart/Chart.yaml")
+		assertErrorIs(t, err, ErrNotFound)
+	},
+	"CommitFiles": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		os.Mkdir(filepath.Join(repo.dir, "chart"), 0o755)
+		repo.writeFile(t, "chart/Chart.yaml", "version: 1.0.0\n")
+		repo.writeFile(t, "build.sh", "echo 1.0.0\n")
+		os.Chmod(filepath.Join(repo.dir, "build.sh"), 0o755)
+		repo.commit(t, "files")
+		repo.git(t, "branch", "release/1.1.0")
+		repo.writeFile(t, "build.sh", "uncommitted\n")
+
+		files := map[string][]byte{
+			"chart/Chart.yaml": []byte("version: 1.1.0\n"),
+			"build.sh":         []byte("echo 1.1.0\n"),
+		}
+		assertNoError(t, gitCtx.CommitFiles("release/1.1.0", files, "Bump version to 1.1.0"))
+
+		assertEqual(t, repo.git(t, "rev-parse", "main"), repo.git(t, "rev-parse", "release/1.1.0^"))
+		assertEqual(t, "Bump version to 1.1.0", repo.git(t, "log", "-1", "--format=%s", "release/1.1.0"))
+		assertEqual(t, "version: 1.1.0", repo.git(t, "show", "release/1.1.0:chart/Chart.yaml"))
+		assertEqual(t, "100755", strings.Fields(repo.git(t, "ls-tree", "release/1.1.0", "build.sh"))[0])
+		assertEqual(t, "M build.sh", repo.git(t, "status", "--porcelain"))
+
+		assertError(t, gitCtx.CommitFiles("main", files, "Bump version to 1.1.0"))
+	},
+	"State": func(t *testing.T, repo *contractRepo, gitCtx interfaces.GitContext) {
+		assertEqual(t, "", string(must(gitCtx.ReadState("test"))))
+
+		assertNoError(t, gitCtx.WriteState("test", []byte("state")))
+		assertEqual(t, "state", string(must(gitCtx.ReadState("test"))))
+
+		assertNoError(t, gitCtx.RemoveState("test"))
+		assertEqual(t, "", string(must(gitCtx.ReadState("test"))))
+	},
+}
+
+func TestCmdGitContext_Contract(t *testing.T) {
+	runGitContextContract(t, func(t *testing.T) interfaces.GitContext {
+		return NewCmdGitContext()
+	})
+}
+
+func TestNativeGitContext_Contract(t *testing.T) {
+	runGitContextContract(t, func(t *testing.T) interfaces.GitContext {
+		return must(NewNativeGitContext())
+	})
+}
+
+func runGitContextContract(t *testing.T, newGitContext func(t *testing.T) interfaces.GitContext) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	for name, test := range gitContextContract {
+		t.Run(name, func(t *testing.T) {
+			repo := newContractRepo(t)
+			test(t, repo, newGitContext(t))
+		})
+	}
+}
+
+// contractRepo is a repository in a temporary directory, pushing to a bare
+// origin, which is the working directory while a test runs
+type contractRepo struct {
+	dir string
+}
+
+func newContractRepo(t *testing.T) *contractRepo {
+	root := t.TempDir()
+	repo := &contractRepo{dir: filepath.Join(root, "work")}
+
+	runGit(t, root, "init", "--bare", "--initial-branch=main", "origin.git")
+	runGit(t, root, "init", "--initial-branch=main", "work")
+	repo.git(t, "config", "user.name", "gitrel")
+	repo.git(t, "config", "user.email", "gitrel@example.com")
+	repo.git(t, "config", "commit.gpgsign", "false")
+	repo.git(t, "config", "tag.gpgsign", "false")
+	repo.commit(t, "initial")
+	repo.git(t, "remote", "add", "origin", filepath.Join(root, "origin.git"))
+	repo.git(t, "push", "origin", "main")
+	repo.git(t, "remote", "set-head", "origin", "main")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Chdir(repo.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { os.Chdir(wd) })
+	return repo
+}
+
+func (r *contractRepo) git(t *testing.T, args ...string) string {
+	return runGit(t, r.dir, args...)
+}
+
+func (r *contractRepo) writeFile(t *testing.T, name string, contents string) {
+	t.Helper()
+
+	err := os.WriteFile(filepath.Join(r.dir, name), []byte(contents), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (r *contractRepo) commit(t *testing.T, message string) {
+	r.git(t, "add", "-A")
+	r.git(t, "commit", "--allow-empty", "-m", message)
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+	}
+
+	return strings.TrimSpace(string(output))
+}
+
+// branchNames returns the sorted short names of refs, joined by commas
+func branchNames(branches []interfaces.Ref) string {
+	names := []string{}
+	for _, branch := range branches {
+		names = append(names, branch.ShortName)
+	}
+
+	slices.Sort(names)
+	return strings.Join(names, ",")
+}
+
+func findRef(t *testing.T, branches []interfaces.Ref, shortName string) interfaces.Ref {
+	t.Helper()
+
+	for _, branch := range branches {
+		if branch.ShortName == shortName {
+			return branch
+		}
+	}
+
+	t.Fatalf("branch %s not found in %v", shortName, branches)
+	return interfaces.Ref{}
+}
+
+func must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
+
+func assertEqual[T comparable](t *testing.T, expected T, actual T) {
+	t.Helper()
+
+	if expected != actual {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func assertNoError(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func assertError(t *testing.T, err error) {
+	t.Helper()
+
+	if err == nil {
+		t.Error("expected an error, got none")
+	}
+}
+
+func assertErrorIs(t *testing.T, err error, kind error) {
+	t.Helper()
+
+	if !errors.Is(err, kind) {
+		t.Errorf("expected error to be %v, got %v", kind, err)
+	}
+}
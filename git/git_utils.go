@@ -3,124 +3,580 @@ package git
 import (
 	"fmt"
 	"gitrel/interfaces"
+	"gitrel/pattern"
 	"gitrel/semver"
 	"gitrel/utils"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 )
 
 // getReleases returns an ordered list of releases
 func getReleases(ctx interfaces.GitRelContext) ([]*ReleaseInfo, error) {
-	if ctx.Command().GetOptFetch() && !ctx.Command().GetFetched() {
-		ctx.Output().Printf("Fetching from remote '%s'...\n", ctx.Command().GetOptRemote())
-		err := ctx.Git().FetchRemote(ctx.Command().GetOptRemote())
-		if err != nil {
-			return nil, fmt.Errorf("error fetching from remote: %w", err)
+	remotes, err := releaseRemotes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Command().GetOptFetch() && !ctx.Command().GetFetched() && !ctx.Command().GetOptRemoteOnly() {
+		for _, remote := range remotes {
+			ctx.Output().Printf("Fetching from remote '%s'...\n", remote)
+			err := ctx.Git().FetchRemote(remote)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching from remote: %w", err)
+			}
 		}
 
 		ctx.Command().SetFetched(true)
 	}
 
-	branches, err := ctx.Git().ListAllBranches()
+	// Releases are keyed without build metadata, as builds of a version have
+	// the same precedence and so are the same release
+	releaseMap := make(map[semver.Version]*ReleaseInfo)
+	addRelease := func(version semver.Version, branch ReleaseBranch) {
+		key := version
+		key.Build = ""
+		if _, ok := releaseMap[key]; !ok {
+			releaseMap[key] = &ReleaseInfo{
+				Version:  version,
+				Branches: []ReleaseBranch{},
+			}
+		}
+
+		info := releaseMap[key]
+		info.Branches = append(info.Branches, branch)
+	}
+
+	mode := ctx.Command().GetOptMode()
+	line := ctx.Command().GetOptLine()
+
+	branches, tags, err := listReleaseRefs(remotes, ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error listing branches: %w", err)
+		return nil, err
 	}
 
-	remoteBranchPattern := "remotes/" + ctx.Command().GetOptRemote() + "/" + ctx.Command().GetOptRemoteBranchName()
-	localBranchPattern := ctx.Command().GetOptLocalBranchName()
+	if usesBranches(mode) {
+		localBranchPattern := ctx.Command().GetOptLocalBranchName()
 
-	releaseMap := make(map[string]*ReleaseInfo)
-	for _, branch := range branches {
-		branchType := ""
-		version := ""
-		if version = getVersionFromBranch(branch, remoteBranchPattern); version != "" {
-			branchType = "remote"
-		} else if version = getVersionFromBranch(branch, localBranchPattern); version != "" {
-			branchType = "local"
-		} else {
-			continue
+		for _, branch := range branches {
+			rawVersion, remote, err := getVersionFromRemoteBranch(branch.ShortName, remotes, ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			branchType := "remote"
+			if rawVersion == "" {
+				rawVersion, err = getVersionFromBranch(branch.ShortName, localBranchPattern, ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				branchType = "local"
+			}
+
+			if rawVersion == "" {
+				continue
+			}
+
+			version, err := semver.ParseLine(rawVersion, line)
+			if err != nil {
+				continue
+			}
+
+			releaseBranch := newReleaseBranch(branch, branchType)
+			releaseBranch.Remote = remote
+			addRelease(version, releaseBranch)
 		}
+	}
 
-		if !semver.ValidateSemver(version) {
+	if usesLines(ctx) {
+		err = addLinePatches(releaseMap, tags, ctx)
+		if err != nil {
+			return nil, err
+		}
+	} else if usesTags(mode) {
+		for _, tag := range tags {
+			version, ok, err := getVersionFromTag(tag, ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				continue
+			}
+
+			addRelease(version, ReleaseBranch{BranchName: tag, Type: "tag"})
+		}
+	}
+
+	releaseInfos := utils.MapValues(releaseMap)
+	sort.Slice(releaseInfos, func(i, j int) bool {
+		return releaseInfos[i].Version.LessThan(releaseInfos[j].Version)
+	})
+
+	return releaseInfos, nil
+}
+
+// releaseRemotes returns the remotes that releases are read from, which is
+// every remote with --all-remotes
+func releaseRemotes(ctx interfaces.GitRelContext) ([]string, error) {
+	if !ctx.Command().GetOptAllRemotes() {
+		return []string{ctx.Command().GetOptRemote()}, nil
+	}
+
+	remotes, err := ctx.Git().ListRemotes()
+	if err != nil {
+		return nil, fmt.Errorf("error listing git remotes: %w", err)
+	}
+
+	return remotes, nil
+}
+
+// getVersionFromRemoteBranch returns the version in a remote tracking branch
+// name, and which of the remotes it tracks
+func getVersionFromRemoteBranch(branchName string, remotes []string, ctx interfaces.GitRelContext) (string, string, error) {
+	for _, remote := range remotes {
+		// The remote name is not part of the pattern, as it may hold braces
+		name, ok := strings.CutPrefix(branchName, "remotes/"+remote+"/")
+		if !ok {
 			continue
 		}
 
-		if _, ok := releaseMap[version]; !ok {
-			releaseMap[version] = &ReleaseInfo{
-				Version:  version,
-				Branches: []ReleaseBranch{},
+		rawVersion, err := getVersionFromBranch(name, ctx.Command().GetOptRemoteBranchName(), ctx)
+		if err != nil {
+			return "", "", err
+		}
+
+		if rawVersion != "" {
+			return rawVersion, remote, nil
+		}
+	}
+
+	return "", "", nil
+}
+
+// listReleaseRefs returns the branches and tags that releases are read from:
+// local and remote tracking branches and local tags, or with --remote-only, the
+// branches and tags on the remotes themselves
+func listReleaseRefs(remotes []string, ctx interfaces.GitRelContext) ([]interfaces.Ref, []string, error) {
+	mode := ctx.Command().GetOptMode()
+	branches := []interfaces.Ref{}
+	tags := []string{}
+
+	if ctx.Command().GetOptRemoteOnly() {
+		for _, remote := range remotes {
+			refs, err := ctx.Git().ListRemoteRefs(remote)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error listing remote refs: %w", err)
 			}
+
+			for _, ref := range refs {
+				if !strings.HasPrefix(ref.Name, "refs/tags/") {
+					branches = append(branches, ref)
+				} else if !slices.Contains(tags, ref.ShortName) {
+					tags = append(tags, ref.ShortName)
+				}
+			}
+		}
+
+		return branches, tags, nil
+	}
+
+	if usesBranches(mode) {
+		refs, err := ctx.Git().ListAllBranches()
+		if err != nil {
+			return nil, nil, fmt.Errorf("error listing branches: %w", err)
 		}
 
-		info := releaseMap[version]
+		branches = refs
+	}
+
+	if usesTags(mode) || usesLines(ctx) {
+		localTags, err := ctx.Git().ListTags()
+		if err != nil {
+			return nil, nil, fmt.Errorf("error listing tags: %w", err)
+		}
+
+		tags = localTags
+	}
+
+	return branches, tags, nil
+}
+
+// addLinePatches finds the latest patch tag on each release line, and makes it
+// the version of that line. Earlier patch tags are kept as patches of the
+// line, and tags for lines without a branch are ignored.
+func addLinePatches(releaseMap map[semver.Version]*ReleaseInfo, tags []string, ctx interfaces.GitRelContext) error {
+	line := ctx.Command().GetOptLine()
+	for _, tag := range tags {
+		version, ok, err := getVersionFromTag(tag, ctx)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		info, ok := releaseMap[version.LineBase(line)]
+		if !ok {
+			continue
+		}
+
+		patch := &ReleaseInfo{
+			Version:  version,
+			Branches: []ReleaseBranch{{BranchName: tag, Type: "tag"}},
+		}
+
+		latestTag := info.GetFirstTag()
+		if latestTag != nil && !info.Version.LessThan(version) {
+			info.patches = append(info.patches, patch)
+			continue
+		}
+
+		if latestTag != nil {
+			info.patches = append(info.patches, &ReleaseInfo{
+				Version:  info.Version,
+				Branches: []ReleaseBranch{*latestTag},
+			})
+		}
+
+		info.Version = version
+		info.Branches = utils.FilterSlice(info.Branches, func(b ReleaseBranch) bool {
+			return b.Type != "tag"
+		})
 		info.Branches = append(info.Branches, ReleaseBranch{
-			BranchName: branch,
-			Type:       branchType,
+			BranchName: tag,
+			Type:       "tag",
 		})
 	}
 
-	releases := utils.MapKeys(releaseMap)
-	sort.Slice(releases, func(i, j int) bool {
-		return semver.CompareSemver(releases[i], releases[j])
+	return nil
+}
+
+// getVersionReleases is like getReleases, but with the earlier patches of
+// release lines as releases of their own, marked only by their tag, so that
+// any version can be looked up. The line branch stays with the latest patch.
+func getVersionReleases(ctx interfaces.GitRelContext) ([]*ReleaseInfo, error) {
+	releases, err := getReleases(ctx)
+	if err != nil || !usesLines(ctx) {
+		return releases, err
+	}
+
+	all := []*ReleaseInfo{}
+	for _, release := range releases {
+		all = append(all, release.patches...)
+		all = append(all, release)
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Version.LessThan(all[j].Version)
 	})
 
-	releaseInfos := make([]*ReleaseInfo, 0, len(releases))
-	for _, version := range releases {
-		releaseInfos = append(releaseInfos, releaseMap[version])
+	return all, nil
+}
+
+// findLatestRelease returns the latest of the ordered releases matching a
+// version prefix such as 1, 1.2 or 1.2.3, or the latest release overall for
+// "latest". A prefix only matches whole version parts, so 1.1 does not match
+// 1.10.0.
+func findLatestRelease(prefix string, releases []*ReleaseInfo) (*ReleaseInfo, error) {
+	var latestRelease *ReleaseInfo
+	for _, release := range releases {
+		if prefix == "latest" || matchesVersionPrefix(release.Version.String(), prefix) {
+			latestRelease = release
+		}
 	}
 
-	return releaseInfos, nil
+	if latestRelease == nil {
+		return nil, newError(ErrNotFound, "no release branches found matching prefix: %s", prefix)
+	}
+
+	return latestRelease, nil
+}
+
+func matchesVersionPrefix(version string, prefix string) bool {
+	if prefix == "" || !strings.HasPrefix(version, prefix) {
+		return prefix == ""
+	}
+
+	rest := version[len(prefix):]
+	return rest == "" || strings.ContainsAny(prefix[len(prefix)-1:], ".-+") || strings.ContainsAny(rest[:1], ".-+")
 }
 
 // Function to get the highest version from release branches
-func getHighestVersion(ctx interfaces.GitRelContext) string {
+func getHighestVersion(ctx interfaces.GitRelContext) (semver.Version, error) {
 	releases, err := getReleases(ctx)
 	if err != nil {
-		ctx.Output().Println(err)
-		return ""
+		return semver.Version{}, err
 	}
 
-	var versions []string
-	for _, release := range releases {
-		version := release.Version
-		if semver.ValidateSemver(version) {
-			versions = append(versions, version)
+	if len(releases) > 0 {
+		return releases[len(releases)-1].Version, nil
+	}
+	return semver.Version{}, nil
+}
+
+// Function to find the current branch and determine the version. When tags
+// are in use and HEAD is not on a release branch, a release tag pointing at
+// HEAD is used instead.
+func getCurrentVersionFromBranch(ctx interfaces.GitRelContext) (semver.Version, bool) {
+	branchName, err := ctx.Git().GetCurrentBranch()
+	if err != nil {
+		ctx.Output().Println("Error finding current branch:", err)
+		return semver.Version{}, false
+	}
+
+	if usesBranches(ctx.Command().GetOptMode()) {
+		rawVersion, err := getVersionFromBranch(branchName, ctx.Command().GetOptLocalBranchName(), ctx)
+		if err == nil && rawVersion == "" {
+			rawVersion, err = getVersionFromBranch(branchName, ctx.Command().GetOptRemoteBranchName(), ctx)
+		}
+
+		if err != nil {
+			ctx.Output().Println("Error reading the current version:", err)
+			return semver.Version{}, false
+		}
+
+		version, err := semver.ParseLine(rawVersion, ctx.Command().GetOptLine())
+		if err == nil {
+			return version, true
 		}
 	}
 
-	sort.Slice(versions, func(i, j int) bool {
-		return semver.CompareSemver(versions[i], versions[j])
-	})
+	if usesTags(ctx.Command().GetOptMode()) {
+		tags, err := ctx.Git().ListTagsAt("HEAD")
+		if err != nil {
+			ctx.Output().Println("Error finding tags at HEAD:", err)
+			return semver.Version{}, false
+		}
+
+		var current semver.Version
+		found := false
+		for _, tag := range tags {
+			version, ok, err := getVersionFromTag(tag, ctx)
+			if err != nil {
+				ctx.Output().Println("Error reading the current version:", err)
+				return semver.Version{}, false
+			}
 
-	if len(versions) > 0 {
-		return versions[len(versions)-1]
+			if ok && (!found || current.LessThan(version)) {
+				current = version
+				found = true
+			}
+		}
+
+		return current, found
 	}
-	return "0.0.0"
+
+	return semver.Version{}, false
 }
 
-// Function to find the current branch and determine the version
-func getCurrentVersionFromBranch(ctx interfaces.GitRelContext) string {
-	branchName, err := ctx.Git().GetCurrentBranch()
+func usesBranches(mode string) bool {
+	return mode != ModeTags
+}
+
+func usesTags(mode string) bool {
+	return mode == ModeTags || mode == ModeBoth
+}
+
+// usesLines is true if release branches are per major or minor line, with
+// patches marked by tags
+func usesLines(ctx interfaces.GitRelContext) bool {
+	line := ctx.Command().GetOptLine()
+	return usesBranches(ctx.Command().GetOptMode()) && line != "" && line != LinePatch
+}
+
+// branchVersion returns the part of a version that goes into a release branch
+// name, e.g. 1.4 for 1.4.2 when using minor release lines
+func branchVersion(version semver.Version, ctx interfaces.GitRelContext) string {
+	if !usesLines(ctx) {
+		return version.String()
+	}
+
+	return version.Line(ctx.Command().GetOptLine())
+}
+
+// isSameRelease is true if the version belongs to the release, which for
+// release lines is any version on the line
+func isSameRelease(release *ReleaseInfo, version semver.Version, ctx interfaces.GitRelContext) bool {
+	if !usesLines(ctx) {
+		return release.Version.Compare(version) == 0
+	}
+
+	line := ctx.Command().GetOptLine()
+	return release.Version.LineBase(line).Compare(version.LineBase(line)) == 0
+}
+
+func replaceInBranchPattern(branchPattern string, version semver.Version, ctx interfaces.GitRelContext) (string, error) {
+	t, err := compilePattern(branchPattern)
 	if err != nil {
-		ctx.Output().Println("Error finding current branch:", err)
-		return ""
+		return "", err
+	}
+
+	return t.Render(patternFields(version, branchVersion(version, ctx), ctx)), nil
+}
+
+func replaceInTagPattern(tagPattern string, version semver.Version, ctx interfaces.GitRelContext) (string, error) {
+	t, err := compilePattern(tagPattern)
+	if err != nil {
+		return "", err
+	}
+
+	return t.Render(patternFields(version, version.String(), ctx)), nil
+}
+
+func patternFields(version semver.Version, versionText string, ctx interfaces.GitRelContext) pattern.Fields {
+	return pattern.Fields{
+		Version:    versionText,
+		Major:      strconv.FormatUint(version.Major, 10),
+		Minor:      strconv.FormatUint(version.Minor, 10),
+		Patch:      strconv.FormatUint(version.Patch, 10),
+		Prerelease: version.Prerelease,
+		Build:      version.Build,
+		Component:  ctx.Command().GetOptComponent(),
+	}
+}
+
+// compiledPatterns caches templates, as the same few patterns are matched
+// against every branch and tag
+var compiledPatterns = map[string]*pattern.Template{}
+
+// compilePattern compiles a branch or tag name template
+func compilePattern(source string) (*pattern.Template, error) {
+	if t, ok := compiledPatterns[source]; ok {
+		return t, nil
 	}
 
-	version := getVersionFromBranch(branchName, ctx.Command().GetOptLocalBranchName())
-	if version == "" {
-		version = getVersionFromBranch(branchName, ctx.Command().GetOptRemoteBranchName())
+	t, err := pattern.Compile(source)
+	if err != nil {
+		return nil, err
 	}
 
-	return version
+	compiledPatterns[source] = t
+	return t, nil
 }
 
-func replaceInBranchPattern(branchPattern string, version string) string {
-	return fmt.Sprintf(branchPattern, version)
+// compiledTagTemplates caches tagOnRelease templates, which are matched
+// against every tag
+var compiledTagTemplates = map[string]*pattern.Template{}
+
+// compileTagTemplate compiles a tagOnRelease tag or message template
+func compileTagTemplate(source string) (*pattern.Template, error) {
+	if t, ok := compiledTagTemplates[source]; ok {
+		return t, nil
+	}
+
+	t, err := pattern.CompileTag(source)
+	if err != nil {
+		return nil, err
+	}
+
+	compiledTagTemplates[source] = t
+	return t, nil
 }
 
-func getVersionFromBranch(branch string, branchPattern string) string {
-	var version string
-	fmt.Sscanf(branch, branchPattern, &version)
-	return version
+// renderTagTemplate expands a tagOnRelease tag or message template, which
+// takes the same placeholders as tag names plus %n for the sequence number
+func renderTagTemplate(template string, version semver.Version, sequence int, ctx interfaces.GitRelContext) (string, error) {
+	t, err := compileTagTemplate(template)
+	if err != nil {
+		return "", err
+	}
+
+	fields := patternFields(version, version.String(), ctx)
+	fields.Sequence = strconv.Itoa(sequence)
+	return t.Render(fields), nil
+}
+
+// nextTagSequence returns the sequence number to use for the next tag of a
+// version, one more than the highest existing %n for that version
+func nextTagSequence(template string, version semver.Version, tags []string, ctx interfaces.GitRelContext) (int, error) {
+	t, err := compileTagTemplate(template)
+	if err != nil || !t.Has("sequence") {
+		return 1, err
+	}
+
+	highest := 0
+	for _, tag := range tags {
+		fields, ok := t.Parse(tag)
+		if !ok {
+			continue
+		}
+
+		// The tag is for this version if the version gives the same name
+		n, _ := strconv.Atoi(fields.Sequence)
+		rendered, err := renderTagTemplate(template, version, n, ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		if rendered == tag && n > highest {
+			highest = n
+		}
+	}
+
+	return highest + 1, nil
+}
+
+// isPushTag is true if a tag was put on a push to a release by tagOnRelease,
+// e.g. v1.2.0+3 for v%v+%n, and so does not mark a release of its own
+func isPushTag(tag string, ctx interfaces.GitRelContext) (bool, error) {
+	template := ctx.Command().GetOptTagOnRelease()
+	if template == "" || template == ctx.Command().GetOptTagName() {
+		return false, nil
+	}
+
+	t, err := compileTagTemplate(template)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := t.Parse(tag)
+	return ok, nil
+}
+
+// getVersionFromTag returns the version of a release tag, or false if the tag
+// is not a release tag
+func getVersionFromTag(tag string, ctx interfaces.GitRelContext) (semver.Version, bool, error) {
+	pushTag, err := isPushTag(tag, ctx)
+	if err != nil || pushTag {
+		return semver.Version{}, false, err
+	}
+
+	rawVersion, err := getVersionFromBranch(tag, ctx.Command().GetOptTagName(), ctx)
+	if err != nil {
+		return semver.Version{}, false, err
+	}
+
+	version, err := semver.Parse(rawVersion)
+	if err != nil {
+		return semver.Version{}, false, nil
+	}
+
+	return version, true, nil
+}
+
+// getVersionFromBranch returns the version text held in a branch or tag name,
+// or an empty string if the name does not match the pattern or belongs to a
+// different component than the configured one
+func getVersionFromBranch(branch string, branchPattern string, ctx interfaces.GitRelContext) (string, error) {
+	t, err := compilePattern(branchPattern)
+	if err != nil {
+		return "", err
+	}
+
+	fields, ok := t.Parse(branch)
+	if !ok {
+		return "", nil
+	}
+
+	if t.Has("component") && fields.Component != ctx.Command().GetOptComponent() {
+		return "", nil
+	}
+
+	return fields.VersionText(), nil
 }
 
 func getOrCreateLocalBranch(release *ReleaseInfo, ctx interfaces.GitRelContext) (*ReleaseBranch, error) {
@@ -131,12 +587,16 @@ func getOrCreateLocalBranch(release *ReleaseInfo, ctx interfaces.GitRelContext)
 
 	remoteBranch := release.GetFirstRemoteBranch()
 	if remoteBranch == nil {
-		return nil, fmt.Errorf("no remote branch found for release: %s", release.Version)
+		return nil, newError(ErrNotFound, "no remote branch found for release: %s", release.Version)
 	}
 
 	// Create the local branch
-	localBranchName := replaceInBranchPattern(ctx.Command().GetOptLocalBranchName(), release.Version)
-	err := ctx.Git().CreateBranchAt(localBranchName, remoteBranch.BranchName)
+	localBranchName, err := replaceInBranchPattern(ctx.Command().GetOptLocalBranchName(), release.Version, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ctx.Git().CreateBranchAt(localBranchName, remoteBranch.BranchName)
 	if err != nil {
 		return nil, fmt.Errorf("error creating local branch: %w", err)
 	}
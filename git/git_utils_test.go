@@ -0,0 +1,204 @@
+package git
+
+import (
+	"gitrel/gitrel_test"
+	"gitrel/semver"
+	"testing"
+)
+
+func TestRenderTagTemplate_ReplacesVersionAndSequence(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	version := semver.MustParse("1.2.3")
+
+	// Act
+	actual, err := renderTagTemplate("v%v+%n (build %n)", version, 4, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error rendering template: %v", err)
+	}
+
+	if actual != "v1.2.3+4 (build 4)" {
+		t.Fatalf("expected 'v1.2.3+4 (build 4)', got '%v'", actual)
+	}
+}
+
+func TestRenderTagTemplate_ReplacesNamedPlaceholders(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.CommandContext.Component = "api"
+	version := semver.MustParse("1.2.3")
+
+	// Act
+	actual, err := renderTagTemplate("{component}/v{major}.{minor}.{patch}+%n", version, 2, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error rendering template: %v", err)
+	}
+
+	if actual != "api/v1.2.3+2" {
+		t.Fatalf("expected 'api/v1.2.3+2', got '%v'", actual)
+	}
+}
+
+func TestNextTagSequence_CountsFromHighestExistingTag(t *testing.T) {
+	for _, template := range []string{"v%v+%n", "v{version}+%n", "v{major}.{minor}.{patch}+%n"} {
+		// Arrange
+		ctx := gitrel_test.DefaultTestGitRelContext(t)
+		version := semver.MustParse("1.2.3")
+		tags := []string{
+			"v1.2.3+1",
+			"v1.2.3+3",
+			"v1.2.30+9",
+			"v1.2.4+7",
+			"v1.2.3+x",
+		}
+
+		// Act
+		actual, err := nextTagSequence(template, version, tags, ctx)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("%s: error finding sequence: %v", template, err)
+		}
+
+		if actual != 4 {
+			t.Fatalf("%s: expected 4, got %v", template, actual)
+		}
+	}
+}
+
+func TestNextTagSequence_StartsAtOne(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+
+	// Act
+	actual, err := nextTagSequence("v%v+%n", semver.MustParse("1.0.0"), []string{"v0.9.0+2"}, ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error finding sequence: %v", err)
+	}
+
+	if actual != 1 {
+		t.Fatalf("expected 1, got %v", actual)
+	}
+}
+
+func TestFindLatestRelease_MatchesWholeVersionParts(t *testing.T) {
+	// Arrange
+	releases := []*ReleaseInfo{
+		{Version: semver.MustParse("1.1.0")},
+		{Version: semver.MustParse("1.1.2")},
+		{Version: semver.MustParse("1.10.0")},
+	}
+
+	// Act
+	actual, err := findLatestRelease("1.1", releases)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error finding release: %v", err)
+	}
+
+	if actual.Version.String() != "1.1.2" {
+		t.Fatalf("expected 1.1.2, got %v", actual.Version)
+	}
+}
+
+func TestFindLatestRelease_MatchesPrereleasePrefix(t *testing.T) {
+	// Arrange
+	releases := []*ReleaseInfo{
+		{Version: semver.MustParse("1.2.0-rc.1")},
+		{Version: semver.MustParse("1.2.0-rc.2")},
+		{Version: semver.MustParse("1.2.0")},
+	}
+
+	// Act
+	actual, err := findLatestRelease("1.2.0-rc", releases)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error finding release: %v", err)
+	}
+
+	if actual.Version.String() != "1.2.0-rc.2" {
+		t.Fatalf("expected 1.2.0-rc.2, got %v", actual.Version)
+	}
+}
+
+func TestGetVersionFromBranch_OnlyMatchesTheConfiguredComponent(t *testing.T) {
+	tests := []struct {
+		component string
+		branch    string
+		expected  string
+	}{
+		{"api", "api/release/1.0.0", "1.0.0"},
+		{"api", "web/release/1.0.0", ""},
+		{"", "api/release/1.0.0", ""},
+	}
+
+	for _, test := range tests {
+		// Arrange
+		ctx := gitrel_test.DefaultTestGitRelContext(t)
+		ctx.CommandContext.Component = test.component
+
+		// Act
+		actual, err := getVersionFromBranch(test.branch, "{component}/release/{version}", ctx)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("error reading %s: %v", test.branch, err)
+		}
+
+		if actual != test.expected {
+			t.Fatalf("component %q, branch %s: expected %q, got %q", test.component, test.branch, test.expected, actual)
+		}
+	}
+}
+
+func TestGetVersionFromBranch_ReturnsErrorForInvalidPattern(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+
+	// Act
+	_, err := getVersionFromBranch("release/1.0.0", "release/{verison}", ctx)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestGetReleases_MergesBuildsOfTheSameVersion(t *testing.T) {
+	// Arrange
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	ctx.GitContext.Branches = []string{
+		"main",
+		"release/1.2.3+a",
+		"release/1.2.3+b",
+		"release/1.2.4",
+	}
+
+	// Act
+	releases, err := getReleases(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error listing releases: %v", err)
+	}
+
+	if len(releases) != 2 {
+		t.Fatalf("expected 2 releases, got %d", len(releases))
+	}
+
+	if releases[0].Version.String() != "1.2.3+a" || len(releases[0].Branches) != 2 {
+		t.Fatalf("expected 1.2.3+a with both branches, got %v with %v", releases[0].Version, releases[0].Branches)
+	}
+
+	if !isSameRelease(releases[0], semver.MustParse("1.2.3+b"), ctx) {
+		t.Fatal("expected 1.2.3+b to be the same release as 1.2.3+a")
+	}
+}
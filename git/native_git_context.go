@@ -0,0 +1,615 @@
+package git
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"gitrel/interfaces"
+	"slices"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const (
+	BackendExec   = "exec"
+	BackendNative = "native"
+)
+
+// NativeGitContext reads and writes refs directly with go-git, so listing
+// branches and tags doesn't depend on parsing git's output. Calls that change
+// the working tree or talk to a remote still run git, so hooks, credentials,
+// merges and cherry-picks behave exactly as they do on the command line, and
+// so do diffs, so they are formatted and follow renames the same way.
+type NativeGitContext struct {
+	*CmdGitContext
+	repo *gogit.Repository
+}
+
+// NewNativeGitContext opens the repository containing the working directory
+func NewNativeGitContext() (*NativeGitContext, error) {
+	repo, err := gogit.PlainOpenWithOptions(".", &gogit.PlainOpenOptions{
+		DetectDotGit:          true,
+		EnableDotGitCommonDir: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error opening repository: %w", err)
+	}
+
+	return &NativeGitContext{
+		CmdGitContext: NewCmdGitContext(),
+		repo:          repo,
+	}, nil
+}
+
+func (c *NativeGitContext) ListAllBranches() ([]interfaces.Ref, error) {
+	refs, err := c.repo.References()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := c.repo.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	branches := []interfaces.Ref{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		// Skip symbolic refs such as refs/remotes/origin/HEAD
+		if ref.Type() != plumbing.HashReference || !(ref.Name().IsBranch() || ref.Name().IsRemote()) {
+			return nil
+		}
+
+		commit, err := c.repo.CommitObject(ref.Hash())
+		if err != nil {
+			return err
+		}
+
+		branch := interfaces.Ref{
+			Name:      ref.Name().String(),
+			ShortName: interfaces.ShortRefName(ref.Name().String()),
+			SHA:       ref.Hash().String(),
+			Date:      commit.Committer.When,
+		}
+
+		if branchConfig, ok := cfg.Branches[ref.Name().Short()]; ok && ref.Name().IsBranch() && branchConfig.Remote != "" {
+			err = c.addUpstream(&branch, branchConfig)
+			if err != nil {
+				return err
+			}
+		}
+
+		branches = append(branches, branch)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(branches, func(a, b interfaces.Ref) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return branches, nil
+}
+
+// addUpstream sets the upstream of a local branch, and how far ahead and behind
+// it the branch is if its remote tracking branch exists
+func (c *NativeGitContext) addUpstream(branch *interfaces.Ref, branchConfig *config.Branch) error {
+	branch.Upstream = branchConfig.Remote + "/" + branchConfig.Merge.Short()
+
+	upstream, err := c.repo.Reference(plumbing.NewRemoteReferenceName(branchConfig.Remote, branchConfig.Merge.Short()), true)
+	if err != nil {
+		return nil
+	}
+
+	if upstream.Hash().String() == branch.SHA {
+		return nil
+	}
+
+	ahead, behind, err := c.symmetricDifference(plumbing.NewHash(branch.SHA), upstream.Hash())
+	if err != nil {
+		return err
+	}
+
+	branch.Ahead = len(ahead)
+	branch.Behind = len(behind)
+	return nil
+}
+
+const (
+	sideLeft = 1 << iota
+	sideRight
+	sideBoth = sideLeft | sideRight
+)
+
+// queuedCommit is a commit waiting to be walked, numbered in the order it was
+// queued so commits with the same date come out in that order, as in git
+type queuedCommit struct {
+	commit *object.Commit
+	order  int
+}
+
+// commitQueue orders commits newest first by committer date
+type commitQueue []queuedCommit
+
+func (q commitQueue) Len() int { return len(q) }
+
+func (q commitQueue) Less(i, j int) bool {
+	if !q[i].commit.Committer.When.Equal(q[j].commit.Committer.When) {
+		return q[i].commit.Committer.When.After(q[j].commit.Committer.When)
+	}
+
+	return q[i].order < q[j].order
+}
+
+func (q commitQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *commitQueue) Push(x any) { *q = append(*q, x.(queuedCommit)) }
+
+func (q *commitQueue) Pop() any {
+	old := *q
+	last := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return last
+}
+
+// symmetricDifference returns the commits reachable from only left and from
+// only right, each newest first by committer date. Like git, it walks back
+// from both by committer date, trusting commits not to be dated before their
+// parents, and stops at their merge base rather than reading all of history.
+// A zero left hash walks all of right's history.
+func (c *NativeGitContext) symmetricDifference(left plumbing.Hash, right plumbing.Hash) ([]*object.Commit, []*object.Commit, error) {
+	sides := map[plumbing.Hash]int{}
+	queue := &commitQueue{}
+	queued := 0
+
+	mark := func(hash plumbing.Hash, side int) error {
+		if sides[hash]&side == side {
+			return nil
+		}
+
+		commit, err := c.repo.CommitObject(hash)
+		if err != nil {
+			return err
+		}
+
+		sides[hash] |= side
+		heap.Push(queue, queuedCommit{commit: commit, order: queued})
+		queued++
+		return nil
+	}
+
+	if !left.IsZero() {
+		err := mark(left, sideLeft)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err := mark(right, sideRight)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Commits are kept in the order they are first walked. Once only commits
+	// reachable from both are left, the walk goes on until they are older than
+	// every commit found on one side, as those could still be reachable from
+	// them when dates are equal.
+	walked := []*object.Commit{}
+	seen := map[plumbing.Hash]bool{}
+	var oldestOneSided time.Time
+	for queue.Len() > 0 {
+		onlyShared := !slices.ContainsFunc(*queue, func(q queuedCommit) bool { return sides[q.commit.Hash] != sideBoth })
+		if onlyShared && (oldestOneSided.IsZero() || (*queue)[0].commit.Committer.When.Before(oldestOneSided)) {
+			break
+		}
+
+		commit := heap.Pop(queue).(queuedCommit).commit
+		if !seen[commit.Hash] {
+			seen[commit.Hash] = true
+			walked = append(walked, commit)
+		}
+
+		if sides[commit.Hash] != sideBoth && (oldestOneSided.IsZero() || commit.Committer.When.Before(oldestOneSided)) {
+			oldestOneSided = commit.Committer.When
+		}
+
+		for _, parent := range commit.ParentHashes {
+			err = mark(parent, sides[commit.Hash])
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	leftOnly := []*object.Commit{}
+	rightOnly := []*object.Commit{}
+	for _, commit := range walked {
+		switch sides[commit.Hash] {
+		case sideLeft:
+			leftOnly = append(leftOnly, commit)
+		case sideRight:
+			rightOnly = append(rightOnly, commit)
+		}
+	}
+
+	return leftOnly, rightOnly, nil
+}
+
+// BranchExists checks for a local branch, or a remote tracking branch when
+// given as remotes/<remote>/<branch>
+func (c *NativeGitContext) BranchExists(branchName string) (bool, error) {
+	refName := plumbing.NewBranchReferenceName(branchName)
+	if strings.HasPrefix(branchName, "remotes/") {
+		refName = plumbing.ReferenceName("refs/" + branchName)
+	}
+
+	_, err := c.repo.Reference(refName, false)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// CreateBranchAt creates a branch without checking it out. Like git, a branch
+// created from a remote tracking branch is set up to track it.
+func (c *NativeGitContext) CreateBranchAt(branchName string, commitish string) error {
+	refName := plumbing.NewBranchReferenceName(branchName)
+	_, err := c.repo.Reference(refName, false)
+	if err == nil {
+		return newError(ErrAlreadyExists, "a branch named '%s' already exists", branchName)
+	}
+
+	hash, err := c.resolve(commitish)
+	if err != nil {
+		return err
+	}
+
+	err = c.repo.Storer.SetReference(plumbing.NewHashReference(refName, hash))
+	if err != nil {
+		return err
+	}
+
+	remote, merge, ok := c.remoteTrackingBranch(commitish)
+	if !ok {
+		return nil
+	}
+
+	err = c.repo.CreateBranch(&config.Branch{Name: branchName, Remote: remote, Merge: merge})
+	if errors.Is(err, gogit.ErrBranchExists) {
+		return nil
+	}
+
+	return err
+}
+
+// remoteTrackingBranch returns the remote and branch a commit-ish names if it
+// is a remote tracking branch, e.g. origin/main or remotes/origin/main
+func (c *NativeGitContext) remoteTrackingBranch(commitish string) (string, plumbing.ReferenceName, bool) {
+	name := strings.TrimPrefix(strings.TrimPrefix(commitish, "refs/"), "remotes/")
+
+	ref, err := c.repo.Reference(plumbing.ReferenceName("refs/remotes/"+name), false)
+	if err != nil || ref.Type() != plumbing.HashReference {
+		return "", "", false
+	}
+
+	remotes, err := c.ListRemotes()
+	if err != nil {
+		return "", "", false
+	}
+
+	for _, remote := range remotes {
+		if branch, ok := strings.CutPrefix(name, remote+"/"); ok {
+			return remote, plumbing.NewBranchReferenceName(branch), true
+		}
+	}
+
+	return "", "", false
+}
+
+func (c *NativeGitContext) DeleteBranch(branchName string) error {
+	refName := plumbing.NewBranchReferenceName(branchName)
+	_, err := c.repo.Reference(refName, false)
+	if err != nil {
+		return newError(ErrNotFound, "branch '%s' not found", branchName)
+	}
+
+	current, err := c.GetCurrentBranch()
+	if err != nil {
+		return err
+	}
+
+	if current == branchName {
+		return fmt.Errorf("cannot delete branch '%s' as it is checked out", branchName)
+	}
+
+	err = c.repo.Storer.RemoveReference(refName)
+	if err != nil {
+		return err
+	}
+
+	err = c.repo.DeleteBranch(branchName)
+	if errors.Is(err, gogit.ErrBranchNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// GetCurrentBranch returns the branch HEAD points to, or HEAD if it's
+// detached, matching git rev-parse --abbrev-ref HEAD
+func (c *NativeGitContext) GetCurrentBranch() (string, error) {
+	head, err := c.repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return "", err
+	}
+
+	if head.Type() == plumbing.SymbolicReference {
+		return head.Target().Short(), nil
+	}
+
+	return "HEAD", nil
+}
+
+func (c *NativeGitContext) ListRemotes() ([]string, error) {
+	remotes, err := c.repo.Remotes()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(remotes))
+	for _, remote := range remotes {
+		names = append(names, remote.Config().Name)
+	}
+
+	slices.Sort(names)
+	return names, nil
+}
+
+func (c *NativeGitContext) GetUpstreamRemote(branchName string) (string, error) {
+	cfg, err := c.repo.Config()
+	if err != nil {
+		return "", err
+	}
+
+	branchConfig, ok := cfg.Branches[branchName]
+	if !ok || branchConfig.Remote == "." {
+		return "", nil
+	}
+
+	return branchConfig.Remote, nil
+}
+
+func (c *NativeGitContext) GetDefaultBranch(remote string) (string, error) {
+	head, err := c.repo.Reference(plumbing.ReferenceName("refs/remotes/"+remote+"/HEAD"), false)
+	if err != nil || head.Type() != plumbing.SymbolicReference {
+		return "", fmt.Errorf("could not determine the default branch of %s. try running 'git remote set-head %s --auto'", remote, remote)
+	}
+
+	return strings.TrimPrefix(head.Target().String(), "refs/remotes/"+remote+"/"), nil
+}
+
+func (c *NativeGitContext) ListTags() ([]string, error) {
+	refs, err := c.repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []string{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		tags = append(tags, ref.Name().Short())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(tags)
+	return tags, nil
+}
+
+// ListTagsAt returns the tags that point at a commit, either directly or
+// through an annotated tag
+func (c *NativeGitContext) ListTagsAt(commitish string) ([]string, error) {
+	hash, err := c.resolve(commitish)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := c.repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []string{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		target := ref.Hash()
+		if tag, err := c.repo.TagObject(target); err == nil {
+			target = tag.Target
+		}
+
+		if target == hash {
+			tags = append(tags, ref.Name().Short())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(tags)
+	return tags, nil
+}
+
+// CreateTag creates an annotated tag, tagged by the user.name and user.email
+// in the git config
+func (c *NativeGitContext) CreateTag(tagName string, commitish string, message string) error {
+	if commitish == "" {
+		commitish = "HEAD"
+	}
+
+	hash, err := c.resolve(commitish)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.repo.CreateTag(tagName, hash, &gogit.CreateTagOptions{Message: message})
+	if errors.Is(err, gogit.ErrTagExists) {
+		return newError(ErrAlreadyExists, "tag '%s' already exists", tagName)
+	}
+
+	return err
+}
+
+func (c *NativeGitContext) DeleteTag(tagName string) error {
+	err := c.repo.DeleteTag(tagName)
+	if errors.Is(err, gogit.ErrTagNotFound) {
+		return newError(ErrNotFound, "tag '%s' not found", tagName)
+	}
+
+	return err
+}
+
+func (c *NativeGitContext) ResolveCommit(commitish string) (string, error) {
+	hash, err := c.resolve(commitish)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.String(), nil
+}
+
+func (c *NativeGitContext) IsAncestor(ancestor string, commitish string) (bool, error) {
+	ancestorHash, err := c.resolve(ancestor)
+	if err != nil {
+		return false, err
+	}
+
+	hash, err := c.resolve(commitish)
+	if err != nil {
+		return false, err
+	}
+
+	notContained, _, err := c.symmetricDifference(ancestorHash, hash)
+	if err != nil {
+		return false, err
+	}
+
+	return len(notContained) == 0, nil
+}
+
+func (c *NativeGitContext) CountCommits(from string, to string) (int, error) {
+	commits, err := c.commitsBetween(from, to)
+	return len(commits), err
+}
+
+// ListCommits lists commits newest first by committer date, as git log does
+func (c *NativeGitContext) ListCommits(from string, to string) ([]interfaces.Commit, error) {
+	between, err := c.commitsBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]interfaces.Commit, 0, len(between))
+	for _, commit := range between {
+		subject, body := splitMessage(commit.Message)
+		commits = append(commits, interfaces.Commit{
+			SHA:         commit.Hash.String(),
+			Author:      commit.Author.Name,
+			AuthorEmail: commit.Author.Email,
+			Date:        commit.Author.When,
+			Subject:     subject,
+			Body:        body,
+		})
+	}
+
+	return commits, nil
+}
+
+// commitsBetween returns the commits reachable from to but not from from, or
+// every commit reachable from to if from is empty
+func (c *NativeGitContext) commitsBetween(from string, to string) ([]*object.Commit, error) {
+	hash, err := c.resolve(to)
+	if err != nil {
+		return nil, err
+	}
+
+	fromHash := plumbing.ZeroHash
+	if from != "" {
+		fromHash, err = c.resolve(from)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, commits, err := c.symmetricDifference(fromHash, hash)
+	return commits, err
+}
+
+func (c *NativeGitContext) RefDate(refName string) (time.Time, error) {
+	ref, err := c.repo.Reference(plumbing.ReferenceName(refName), true)
+	if err != nil {
+		return time.Time{}, newError(ErrNotFound, "ref %s not found", refName)
+	}
+
+	if tag, err := c.repo.TagObject(ref.Hash()); err == nil {
+		return tag.Tagger.When, nil
+	}
+
+	commit, err := c.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return commit.Committer.When, nil
+}
+
+func (c *NativeGitContext) ReadFile(commitish string, path string) ([]byte, error) {
+	hash, err := c.resolve(commitish)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := c.repo.CommitObject(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := commit.File(path)
+	if err != nil {
+		return nil, newError(ErrNotFound, "%s not found at %s", path, commitish)
+	}
+
+	contents, err := file.Contents()
+	return []byte(contents), err
+}
+
+// splitMessage splits a commit message into its subject, which like git's is
+// the first paragraph on one line, and its body
+func splitMessage(message string) (string, string) {
+	message = strings.TrimSpace(message)
+	subject, body, _ := strings.Cut(message, "\n\n")
+	return strings.ReplaceAll(subject, "\n", " "), strings.TrimSpace(body)
+}
+
+// resolve returns the commit a commit-ish points to, peeling annotated tags
+func (c *NativeGitContext) resolve(commitish string) (plumbing.Hash, error) {
+	hash, err := c.repo.ResolveRevision(plumbing.Revision(commitish))
+	if err != nil {
+		return plumbing.ZeroHash, newError(ErrNotFound, "not a valid commit: %s", commitish)
+	}
+
+	_, err = object.GetCommit(c.repo.Storer, *hash)
+	if err != nil {
+		return plumbing.ZeroHash, newError(ErrNotFound, "not a valid commit: %s", commitish)
+	}
+
+	return *hash, nil
+}
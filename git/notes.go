@@ -0,0 +1,163 @@
+package git
+
+import (
+	"fmt"
+	"gitrel/interfaces"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// defaultIssuePattern matches issue keys in commit messages unless
+// issuePattern is set: Jira style keys with at least two letters such as
+// GITREL-123, and GitHub style references such as #123
+var defaultIssuePattern = regexp.MustCompile(`\b[A-Z]{2}[A-Z0-9]*-[0-9]+\b|#[0-9]+\b`)
+
+// wellKnownPrefixes are names like UTF-8, SHA-256, ISO-8601 and CVE-2024-1234
+// that the default pattern would take for issue keys
+var wellKnownPrefixes = []string{"AES", "CVE", "CWE", "ECMA", "IEC", "IEEE", "ISO", "MD", "PEP", "RFC", "SHA", "UTF"}
+
+// DefaultNotesTemplate is used for release notes when no template is given
+const DefaultNotesTemplate = `# {{.Version}} ({{.Date}})
+{{- if .Previous}}
+
+Changes since {{.Previous}}.
+{{- end}}
+{{- range .Groups}}
+
+## {{.Title}}
+{{range .Entries}}
+- {{if .Breaking}}**BREAKING:** {{end}}{{if .Scope}}**{{.Scope}}:** {{end}}{{.Description}} ({{short .SHA}})
+{{- end}}
+{{- end}}
+{{- if .Issues}}
+
+## Issues
+
+{{join .Issues ", "}}
+{{- end}}
+{{- if .Authors}}
+
+## Contributors
+{{range .Authors}}
+- {{.}}
+{{- end}}
+{{- end}}
+`
+
+// ReleaseNotesData is what a release notes template is rendered with
+type ReleaseNotesData struct {
+	Version  string
+	Previous string // The release before, if there is one
+	Date     string // Date of the release tag or branch, as YYYY-MM-DD
+	Groups   []ChangelogGroup
+	Commits  []interfaces.Commit // Every commit in the release, newest first
+	Authors  []string            // Commit authors, in the order of their first commit
+	Issues   []string            // Issue keys found in commit messages, in order
+}
+
+// notesFuncs are the functions release notes templates can call besides the
+// text/template builtins
+var notesFuncs = template.FuncMap{
+	"short": shortSHA,
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// GetReleaseNotes renders the notes for a release, given as a version prefix,
+// latest or current, with the template file configured or the default one
+func GetReleaseNotes(versionish string, ctx interfaces.GitRelContext) (*ReleaseNotes, error) {
+	source := DefaultNotesTemplate
+	if path := ctx.Command().GetOptNotesTemplate(); path != "" {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading template: %w", err)
+		}
+
+		source = string(contents)
+	}
+
+	notesTemplate, err := template.New("notes").Funcs(notesFuncs).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing template: %w", err)
+	}
+
+	changelog, err := GetChangelog("", versionish, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := strings.Builder{}
+	err = notesTemplate.Execute(&notes, newReleaseNotesData(changelog))
+	if err != nil {
+		return nil, fmt.Errorf("error rendering template: %w", err)
+	}
+
+	return &ReleaseNotes{
+		Version:  changelog.Version,
+		Previous: changelog.Previous,
+		Notes:    notes.String(),
+	}, nil
+}
+
+func newReleaseNotesData(changelog *Changelog) ReleaseNotesData {
+	data := ReleaseNotesData{
+		Version:  changelog.Version,
+		Previous: changelog.Previous,
+		Date:     changelog.Date,
+		Groups:   changelog.Groups,
+		Commits:  changelog.commits,
+		Authors:  []string{},
+		Issues:   []string{},
+	}
+
+	// Commits are newest first, so walk them backwards to list the authors
+	// and issues in the order they appeared. Commits left out of the
+	// changelog still count.
+	for i := len(changelog.commits) - 1; i >= 0; i-- {
+		commit := changelog.commits[i]
+		if commit.Author != "" && !slices.Contains(data.Authors, commit.Author) {
+			data.Authors = append(data.Authors, commit.Author)
+		}
+
+		for _, issue := range issueKeys(commit.Subject+"\n"+commit.Body, changelog.issuePattern) {
+			if !slices.Contains(data.Issues, issue) {
+				data.Issues = append(data.Issues, issue)
+			}
+		}
+	}
+
+	return data
+}
+
+// issueKeys returns the issue keys in a commit message, without duplicates.
+// Without a pattern, the default one is used, leaving out well-known names.
+func issueKeys(message string, pattern *regexp.Regexp) []string {
+	matches := []string{}
+	if pattern != nil {
+		matches = pattern.FindAllString(message, -1)
+	} else {
+		for _, key := range defaultIssuePattern.FindAllString(message, -1) {
+			prefix, _, _ := strings.Cut(key, "-")
+			if !slices.Contains(wellKnownPrefixes, prefix) {
+				matches = append(matches, key)
+			}
+		}
+	}
+
+	keys := []string{}
+	for _, key := range matches {
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return keys
+}
@@ -0,0 +1,36 @@
+package git
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestIssueKeys_LeavesOutWellKnownNames(t *testing.T) {
+	// Arrange
+	message := "fix: read UTF-8 and SHA-256 names (GITREL-12)\n\n" +
+		"Dates are ISO-8601, see CVE-2024-1234 and #7. Also fixes GITREL-12 and AB-3, but not X-1."
+
+	// Act
+	actual := issueKeys(message, nil)
+
+	// Assert
+	expected := []string{"GITREL-12", "#7", "AB-3"}
+	if !slices.Equal(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestIssueKeys_UsesPattern(t *testing.T) {
+	// Arrange
+	pattern := regexp.MustCompile(`\b(?:UTF|ops)-[0-9]+\b`)
+
+	// Act
+	actual := issueKeys("fix: UTF-8 in ops-42 (GITREL-12, #7)", pattern)
+
+	// Assert
+	expected := []string{"UTF-8", "ops-42"}
+	if !slices.Equal(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
@@ -0,0 +1,52 @@
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"gitrel/interfaces"
+)
+
+// saveOperationState stores the progress of an interrupted operation, so a
+// later command can continue or abort it
+func saveOperationState(name string, state any, ctx interfaces.GitRelContext) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = ctx.Git().WriteState(name, data)
+	if err != nil {
+		return fmt.Errorf("error saving %s state: %w", name, err)
+	}
+
+	return nil
+}
+
+// loadOperationState reads the progress of an interrupted operation into
+// state, returning false if the operation is not in progress
+func loadOperationState(name string, state any, ctx interfaces.GitRelContext) (bool, error) {
+	data, err := ctx.Git().ReadState(name)
+	if err != nil {
+		return false, fmt.Errorf("error reading %s state: %w", name, err)
+	}
+
+	if data == nil {
+		return false, nil
+	}
+
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return false, fmt.Errorf("error reading %s state: %w", name, err)
+	}
+
+	return true, nil
+}
+
+func clearOperationState(name string, ctx interfaces.GitRelContext) error {
+	err := ctx.Git().RemoveState(name)
+	if err != nil {
+		return fmt.Errorf("error clearing %s state: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,359 @@
+package git
+
+import (
+	"gitrel/interfaces"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PlanGitContext is a GitContext for dry runs. Read calls are answered by the
+// real repository, adjusted for the planned changes so far, while mutating
+// calls are recorded as the equivalent git commands instead of being run.
+type PlanGitContext struct {
+	repo            interfaces.GitContext
+	commands        []string
+	currentBranch   string
+	previousBranch  string
+	createdBranches []string
+	deletedBranches []string
+	createdTags     []string
+	state           map[string][]byte
+}
+
+func NewPlanGitContext(repo interfaces.GitContext) *PlanGitContext {
+	return &PlanGitContext{
+		repo:  repo,
+		state: map[string][]byte{},
+	}
+}
+
+// isDryRun is true when git calls are only being planned, including inside a
+// transaction
+func isDryRun(ctx interfaces.GitRelContext) bool {
+	gitCtx := ctx.Git()
+	if journal, ok := gitCtx.(*journalGitContext); ok {
+		gitCtx = journal.GitContext
+	}
+
+	_, ok := gitCtx.(*PlanGitContext)
+	return ok
+}
+
+// reportChange prints a message for a change made to the repository, or in a
+// dry run the message for the change that would have been made
+func reportChange(ctx interfaces.GitRelContext, done string, planned string, args ...interface{}) {
+	if isDryRun(ctx) {
+		ctx.Output().Printf(planned, args...)
+		return
+	}
+
+	ctx.Output().Printf(done, args...)
+}
+
+// reportPushed confirms a push, which a dry run has nothing to confirm
+func reportPushed(ctx interfaces.GitRelContext) {
+	if !isDryRun(ctx) {
+		ctx.Output().Println("Pushed!")
+	}
+}
+
+// Commands returns the planned git commands, in order
+func (c *PlanGitContext) Commands() []string {
+	return c.commands
+}
+
+func (c *PlanGitContext) plan(args ...string) {
+	quoted := make([]string, 0, len(args)+1)
+	quoted = append(quoted, "git")
+	for _, arg := range args {
+		quoted = append(quoted, shellQuote(arg))
+	}
+
+	c.commands = append(c.commands, strings.Join(quoted, " "))
+}
+
+// switchTo tracks the planned current branch, so later reads see it
+func (c *PlanGitContext) switchTo(branchName string) error {
+	current, err := c.GetCurrentBranch()
+	if err != nil {
+		return err
+	}
+
+	c.previousBranch = current
+	c.currentBranch = branchName
+	return nil
+}
+
+func (c *PlanGitContext) HasUncommittedChanges() (bool, error) {
+	return c.repo.HasUncommittedChanges()
+}
+
+func (c *PlanGitContext) FetchRemote(remote string) error {
+	c.plan("fetch", remote)
+	return nil
+}
+
+// ListAllBranches includes branches created by the plan by name only, as the
+// commits they would point to don't exist yet
+func (c *PlanGitContext) ListAllBranches() ([]interfaces.Ref, error) {
+	branches, err := c.repo.ListAllBranches()
+	if err != nil {
+		return nil, err
+	}
+
+	branches = slices.DeleteFunc(slices.Clone(branches), func(b interfaces.Ref) bool {
+		return slices.Contains(c.deletedBranches, b.ShortName)
+	})
+
+	for _, branch := range c.createdBranches {
+		branches = append(branches, interfaces.Ref{Name: "refs/heads/" + branch, ShortName: branch})
+	}
+
+	return branches, nil
+}
+
+func (c *PlanGitContext) ListRemoteRefs(remote string) ([]interfaces.Ref, error) {
+	return c.repo.ListRemoteRefs(remote)
+}
+
+func (c *PlanGitContext) BranchExists(branchName string) (bool, error) {
+	if slices.Contains(c.createdBranches, branchName) {
+		return true, nil
+	}
+
+	if slices.Contains(c.deletedBranches, branchName) {
+		return false, nil
+	}
+
+	return c.repo.BranchExists(branchName)
+}
+
+func (c *PlanGitContext) CheckoutBranch(branchName string) error {
+	c.plan("checkout", branchName)
+	return c.switchTo(branchName)
+}
+
+func (c *PlanGitContext) SwitchToNewBranch(branchName string) error {
+	c.plan("switch", "-c", branchName)
+	c.createdBranches = append(c.createdBranches, branchName)
+	return c.switchTo(branchName)
+}
+
+func (c *PlanGitContext) CreateBranchAt(branchName string, commitish string) error {
+	c.plan("branch", branchName, commitish)
+	c.createdBranches = append(c.createdBranches, branchName)
+	return nil
+}
+
+func (c *PlanGitContext) DeleteBranch(branchName string) error {
+	c.plan("branch", "-D", branchName)
+	c.createdBranches = slices.DeleteFunc(c.createdBranches, func(b string) bool { return b == branchName })
+	c.deletedBranches = append(c.deletedBranches, branchName)
+	return nil
+}
+
+func (c *PlanGitContext) SwitchBack() error {
+	c.plan("switch", "-")
+	if c.previousBranch == "" {
+		return nil
+	}
+
+	return c.switchTo(c.previousBranch)
+}
+
+func (c *PlanGitContext) PushBranch(remote string, branchSpec string) error {
+	c.plan("push", remote, branchSpec)
+	return nil
+}
+
+func (c *PlanGitContext) ForcePushBranch(remote string, branchSpec string) error {
+	c.plan("push", "--force-with-lease", remote, branchSpec)
+	return nil
+}
+
+func (c *PlanGitContext) DeleteRemoteRef(remote string, ref string) error {
+	c.plan("push", remote, "--delete", ref)
+	return nil
+}
+
+func (c *PlanGitContext) GetCurrentBranch() (string, error) {
+	if c.currentBranch != "" {
+		return c.currentBranch, nil
+	}
+
+	return c.repo.GetCurrentBranch()
+}
+
+func (c *PlanGitContext) ListRemotes() ([]string, error) {
+	return c.repo.ListRemotes()
+}
+
+func (c *PlanGitContext) GetUpstreamRemote(branchName string) (string, error) {
+	return c.repo.GetUpstreamRemote(branchName)
+}
+
+func (c *PlanGitContext) MergeBranch(branchName string) error {
+	c.plan("merge", branchName)
+	return nil
+}
+
+func (c *PlanGitContext) ContinueMerge() error {
+	c.plan("merge", "--continue")
+	return nil
+}
+
+func (c *PlanGitContext) AbortMerge() error {
+	c.plan("merge", "--abort")
+	return nil
+}
+
+func (c *PlanGitContext) GetDefaultBranch(remote string) (string, error) {
+	return c.repo.GetDefaultBranch(remote)
+}
+
+func (c *PlanGitContext) ListTags() ([]string, error) {
+	tags, err := c.repo.ListTags()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(slices.Clone(tags), c.createdTags...), nil
+}
+
+func (c *PlanGitContext) ListTagsAt(commitish string) ([]string, error) {
+	return c.repo.ListTagsAt(commitish)
+}
+
+func (c *PlanGitContext) CreateTag(tagName string, commitish string, message string) error {
+	args := []string{"tag", "-a", tagName, "-m", message}
+	if commitish != "" {
+		args = append(args, commitish)
+	}
+
+	c.plan(args...)
+	c.createdTags = append(c.createdTags, tagName)
+	return nil
+}
+
+func (c *PlanGitContext) PushTag(remote string, tagName string) error {
+	c.plan("push", remote, "refs/tags/"+tagName)
+	return nil
+}
+
+func (c *PlanGitContext) DeleteTag(tagName string) error {
+	c.plan("tag", "-d", tagName)
+	c.createdTags = slices.DeleteFunc(c.createdTags, func(t string) bool { return t == tagName })
+	return nil
+}
+
+func (c *PlanGitContext) ResetBranch(commit string) error {
+	c.plan("reset", "--hard", commit)
+	return nil
+}
+
+// ResolveCommit resolves against the real repository, so branches created by
+// the plan resolve to the commit they would be created at only if that
+// commit-ish already exists
+func (c *PlanGitContext) ResolveCommit(commitish string) (string, error) {
+	return c.repo.ResolveCommit(commitish)
+}
+
+func (c *PlanGitContext) IsAncestor(ancestor string, commitish string) (bool, error) {
+	return c.repo.IsAncestor(ancestor, commitish)
+}
+
+func (c *PlanGitContext) CountCommits(from string, to string) (int, error) {
+	return c.repo.CountCommits(from, to)
+}
+
+func (c *PlanGitContext) ListCommits(from string, to string) ([]interfaces.Commit, error) {
+	return c.repo.ListCommits(from, to)
+}
+
+func (c *PlanGitContext) Diff(from string, to string) (string, error) {
+	return c.repo.Diff(from, to)
+}
+
+func (c *PlanGitContext) DiffStat(from string, to string) ([]interfaces.FileStat, error) {
+	return c.repo.DiffStat(from, to)
+}
+
+// RefDate dates branches and tags created by the plan now, as that is when
+// they would be made
+func (c *PlanGitContext) RefDate(refName string) (time.Time, error) {
+	if branch, ok := strings.CutPrefix(refName, "refs/heads/"); ok && slices.Contains(c.createdBranches, branch) {
+		return time.Now(), nil
+	}
+
+	if tag, ok := strings.CutPrefix(refName, "refs/tags/"); ok && slices.Contains(c.createdTags, tag) {
+		return time.Now(), nil
+	}
+
+	return c.repo.RefDate(refName)
+}
+
+func (c *PlanGitContext) ReadFile(commitish string, path string) ([]byte, error) {
+	return c.repo.ReadFile(commitish, path)
+}
+
+// CommitFiles is planned as committing the files on the branch checked out,
+// which is what it amounts to
+func (c *PlanGitContext) CommitFiles(branchName string, files map[string][]byte, message string) error {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+
+	slices.Sort(paths)
+	c.plan("switch", branchName)
+	c.plan(append([]string{"commit", "-m", message, "--"}, paths...)...)
+	c.plan("switch", "-")
+	return nil
+}
+
+func (c *PlanGitContext) CherryPick(commit string) error {
+	c.plan("cherry-pick", "-x", commit)
+	return nil
+}
+
+func (c *PlanGitContext) ContinueCherryPick() error {
+	c.plan("cherry-pick", "--continue")
+	return nil
+}
+
+func (c *PlanGitContext) AbortCherryPick() error {
+	c.plan("cherry-pick", "--abort")
+	return nil
+}
+
+// ReadState sees state written earlier in the plan, and otherwise the real
+// state files
+func (c *PlanGitContext) ReadState(name string) ([]byte, error) {
+	if data, ok := c.state[name]; ok {
+		return data, nil
+	}
+
+	return c.repo.ReadState(name)
+}
+
+// WriteState keeps state in memory, so a dry run leaves no state behind
+func (c *PlanGitContext) WriteState(name string, data []byte) error {
+	c.state[name] = data
+	return nil
+}
+
+func (c *PlanGitContext) RemoveState(name string) error {
+	c.state[name] = nil
+	return nil
+}
+
+// shellQuote quotes an argument for display if the shell would split or
+// expand it
+func shellQuote(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n'\"$`\\*?[]{}()<>|&;#~!") {
+		return arg
+	}
+
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
@@ -0,0 +1,115 @@
+package git
+
+import (
+	"gitrel/gitrel_test"
+	"gitrel/interfaces"
+	"slices"
+	"testing"
+)
+
+type planTestContext struct {
+	*gitrel_test.TestGitRelContext
+	plan *PlanGitContext
+}
+
+func (c *planTestContext) Git() interfaces.GitContext {
+	return c.plan
+}
+
+func newPlanTestContext(t *testing.T) *planTestContext {
+	ctx := gitrel_test.DefaultTestGitRelContext(t)
+	return &planTestContext{
+		TestGitRelContext: ctx,
+		plan:              NewPlanGitContext(ctx.GitContext),
+	}
+}
+
+func TestPlanGitContext_PlansNewReleaseWithoutRunningIt(t *testing.T) {
+	// Arrange
+	ctx := newPlanTestContext(t)
+	ctx.CommandContext.TagOnRelease = "v%v"
+
+	// Act
+	_, err := IncrementAndCreateBranch("minor", "", ctx)
+	if err != nil {
+		t.Fatalf("error creating release: %v", err)
+	}
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	expected := []string{
+		"git switch -c release/2.1.0",
+		"git push origin release/2.1.0:release/2.1.0",
+		"git tag -a v2.1.0 -m 'Release 2.1.0' release/2.1.0",
+		"git push origin refs/tags/v2.1.0",
+		"git switch -",
+	}
+	if !slices.Equal(ctx.plan.Commands(), expected) {
+		t.Fatalf("expected plan %v, got %v", expected, ctx.plan.Commands())
+	}
+	ctx.OutputContext.AssertOutputLines(
+		"Would create release branch: release/2.1.0",
+		"Would push release/2.1.0 to origin",
+		"Would tag release/2.1.0 as v2.1.0",
+		"Would push tag v2.1.0 to origin",
+		"Would switch back to branch: main",
+	)
+}
+
+func TestPlanGitContext_ReadsReflectPlannedChanges(t *testing.T) {
+	// Arrange
+	ctx := newPlanTestContext(t)
+
+	// Act
+	_ = ctx.plan.SwitchToNewBranch("release/3.0.0")
+	_ = ctx.plan.CreateTag("v3.0.0", "", "Release 3.0.0")
+	_ = ctx.plan.DeleteBranch("release/1.0.0")
+
+	// Assert
+	current, _ := ctx.plan.GetCurrentBranch()
+	if current != "release/3.0.0" {
+		t.Fatalf("expected current branch release/3.0.0, got %s", current)
+	}
+
+	refs, _ := ctx.plan.ListAllBranches()
+	branches := []string{}
+	for _, ref := range refs {
+		branches = append(branches, ref.ShortName)
+	}
+
+	if !slices.Contains(branches, "release/3.0.0") || slices.Contains(branches, "release/1.0.0") {
+		t.Fatalf("expected planned branch changes in %v", branches)
+	}
+
+	tags, _ := ctx.plan.ListTags()
+	if !slices.Contains(tags, "v3.0.0") {
+		t.Fatalf("expected planned tag in %v", tags)
+	}
+
+	_ = ctx.plan.SwitchBack()
+	current, _ = ctx.plan.GetCurrentBranch()
+	if current != "main" {
+		t.Fatalf("expected to switch back to main, got %s", current)
+	}
+	ctx.GitContext.AssertNoSideEffects()
+}
+
+func TestPlanGitContext_ReportsUpdateAsPlanned(t *testing.T) {
+	// Arrange
+	ctx := newPlanTestContext(t)
+
+	// Act
+	_, err := UpdateVersion("1.0.0", ctx)
+	if err != nil {
+		t.Fatalf("error updating release: %v", err)
+	}
+
+	// Assert
+	ctx.GitContext.AssertNoSideEffects()
+	ctx.OutputContext.AssertOutputLines(
+		"Checking out release/1.0.0...",
+		"Merging main into release/1.0.0...",
+		"Would push release/1.0.0 to origin",
+		"Would switch back to branch: main",
+	)
+}
@@ -1,13 +1,41 @@
 package git
 
+import (
+	"gitrel/interfaces"
+	"gitrel/semver"
+	"strings"
+	"time"
+)
+
+// Release modes, controlling whether releases are marked by branches, tags or
+// both
+const (
+	ModeBranches = "branches"
+	ModeTags     = "tags"
+	ModeBoth     = "both"
+)
+
+// Release line granularities, controlling how much of the version a release
+// branch name holds. With major or minor lines, patches are tags on the line
+// branch.
+const (
+	LineMajor = "major"
+	LineMinor = "minor"
+	LinePatch = "patch"
+)
+
 type ReleaseInfo struct {
-	Version  string
+	Version  semver.Version
 	Branches []ReleaseBranch
+
+	// The earlier patch releases of a release line, with the line's branch
+	// going to the latest patch
+	patches []*ReleaseInfo
 }
 
 func (r *ReleaseInfo) IsLocalOnly() bool {
 	for _, branch := range r.Branches {
-		if branch.Type == "remote" {
+		if branch.Type != "local" {
 			return false
 		}
 	}
@@ -35,7 +63,82 @@ func (r *ReleaseInfo) GetFirstRemoteBranch() *ReleaseBranch {
 	return nil
 }
 
+func (r *ReleaseInfo) GetFirstTag() *ReleaseBranch {
+	for _, branch := range r.Branches {
+		if branch.Type == "tag" {
+			return &branch
+		}
+	}
+
+	return nil
+}
+
+// GetTip returns the branch whose tip commit is known, preferring the local
+// branch, or nil if there is none
+func (r *ReleaseInfo) GetTip() *ReleaseBranch {
+	for _, branchType := range []string{"local", "remote"} {
+		for _, branch := range r.Branches {
+			if branch.Type == branchType && branch.SHA != "" {
+				return &branch
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetReleasePoint returns the ref marking the commit the release was made
+// from: its tag, or else its local or remote branch
+func (r *ReleaseInfo) GetReleasePoint() *ReleaseBranch {
+	if tag := r.GetFirstTag(); tag != nil {
+		return tag
+	}
+
+	if local := r.GetFirstLocalBranch(); local != nil {
+		return local
+	}
+
+	return r.GetFirstRemoteBranch()
+}
+
+func (r *ReleaseInfo) HasBranch() bool {
+	return r.GetFirstLocalBranch() != nil || r.GetFirstRemoteBranch() != nil
+}
+
 type ReleaseBranch struct {
 	BranchName string
-	Type       string // remote or local
+	Type       string // remote, local or tag
+	Remote     string // The remote a remote branch is on
+
+	// The tip of the branch and how it compares to its upstream, when listed
+	// from the repository
+	SHA      string
+	Date     time.Time
+	Upstream string
+	Ahead    int
+	Behind   int
+}
+
+// RefName returns the full name of the branch or tag, e.g. refs/tags/v1.0.0
+func (b *ReleaseBranch) RefName() string {
+	switch {
+	case b.Type == "tag":
+		return "refs/tags/" + b.BranchName
+	case b.Type == "remote" && strings.HasPrefix(b.BranchName, "remotes/"):
+		return "refs/" + b.BranchName
+	}
+
+	return "refs/heads/" + b.BranchName
+}
+
+func newReleaseBranch(ref interfaces.Ref, branchType string) ReleaseBranch {
+	return ReleaseBranch{
+		BranchName: ref.ShortName,
+		Type:       branchType,
+		SHA:        ref.SHA,
+		Date:       ref.Date,
+		Upstream:   ref.Upstream,
+		Ahead:      ref.Ahead,
+		Behind:     ref.Behind,
+	}
 }
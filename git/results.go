@@ -0,0 +1,550 @@
+package git
+
+import (
+	"fmt"
+	"gitrel/interfaces"
+	"gitrel/semver"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReleaseSummary describes one release and the branches and tags it lives on
+type ReleaseSummary struct {
+	Version   string   `json:"version" yaml:"version"`
+	Line      string   `json:"line,omitempty" yaml:"line,omitempty"`
+	Local     []string `json:"local" yaml:"local"`
+	Remote    []string `json:"remote" yaml:"remote"`
+	Tags      []string `json:"tags" yaml:"tags"`
+	Remotes   []string `json:"remotes,omitempty" yaml:"remotes,omitempty"`
+	LocalOnly bool     `json:"localOnly" yaml:"localOnly"`
+	SHA       string   `json:"sha,omitempty" yaml:"sha,omitempty"`
+	Date      string   `json:"date,omitempty" yaml:"date,omitempty"`
+	Current   bool     `json:"current,omitempty" yaml:"current,omitempty"`
+	Latest    bool     `json:"latest,omitempty" yaml:"latest,omitempty"`
+}
+
+func newReleaseSummary(release *ReleaseInfo, ctx interfaces.GitRelContext) ReleaseSummary {
+	summary := ReleaseSummary{
+		Version:   release.Version.String(),
+		Local:     []string{},
+		Remote:    []string{},
+		Tags:      []string{},
+		LocalOnly: release.IsLocalOnly(),
+	}
+
+	if tip := release.GetTip(); tip != nil {
+		summary.SHA = tip.SHA
+		if !tip.Date.IsZero() {
+			summary.Date = tip.Date.Format(time.RFC3339)
+		}
+	}
+
+	if usesLines(ctx) {
+		summary.Line = release.Version.Line(ctx.Command().GetOptLine())
+	}
+
+	for _, branch := range release.Branches {
+		switch branch.Type {
+		case "local":
+			summary.Local = append(summary.Local, branch.BranchName)
+		case "remote":
+			summary.Remote = append(summary.Remote, branch.BranchName)
+			if ctx.Command().GetOptAllRemotes() && !slices.Contains(summary.Remotes, branch.Remote) {
+				summary.Remotes = append(summary.Remotes, branch.Remote)
+			}
+		case "tag":
+			summary.Tags = append(summary.Tags, branch.BranchName)
+		}
+	}
+
+	return summary
+}
+
+// ReleaseList is the result of listing releases, oldest first. With
+// --all-remotes, releases are listed from every remote.
+type ReleaseList struct {
+	Remote     string           `json:"remote" yaml:"remote"`
+	AllRemotes bool             `json:"allRemotes,omitempty" yaml:"allRemotes,omitempty"`
+	Releases   []ReleaseSummary `json:"releases" yaml:"releases"`
+}
+
+func NewReleaseList(releases []*ReleaseInfo, ctx interfaces.GitRelContext) *ReleaseList {
+	list := &ReleaseList{
+		Remote:     ctx.Command().GetOptRemote(),
+		AllRemotes: ctx.Command().GetOptAllRemotes(),
+		Releases:   []ReleaseSummary{},
+	}
+
+	for _, release := range releases {
+		list.Releases = append(list.Releases, newReleaseSummary(release, ctx))
+	}
+
+	return list
+}
+
+func (l *ReleaseList) RenderText(output interfaces.OutputContext) {
+	if len(l.Releases) == 0 {
+		output.Println("No release branches found.")
+		return
+	}
+
+	labels := make([]string, len(l.Releases))
+	width := 0
+	for i, release := range l.Releases {
+		labels[i] = release.Version
+		if release.LocalOnly {
+			labels[i] += " (local only)"
+		} else if l.AllRemotes && len(release.Remotes) > 0 {
+			labels[i] += " (" + strings.Join(release.Remotes, ", ") + ")"
+		}
+
+		width = max(width, len(labels[i]))
+	}
+
+	// Releases with a known tip show its short SHA and commit date alongside.
+	// Releases read from the remote have no date.
+	output.Println("Current release branches:")
+	for i, release := range l.Releases {
+		switch {
+		case release.SHA == "":
+			output.Println(labels[i])
+		case release.Date == "":
+			output.Printf("%-*s  %s\n", width, labels[i], shortSHA(release.SHA))
+		default:
+			date, _ := time.Parse(time.RFC3339, release.Date)
+			output.Printf("%-*s  %s  %s\n", width, labels[i], shortSHA(release.SHA), date.Format(time.DateOnly))
+		}
+	}
+}
+
+// shortSHA abbreviates a commit SHA the way git does by default
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+
+	return sha
+}
+
+// Status is the current and latest version, and every release newest first
+type Status struct {
+	Current  string           `json:"current,omitempty" yaml:"current,omitempty"`
+	Latest   string           `json:"latest,omitempty" yaml:"latest,omitempty"`
+	Remote   string           `json:"remote" yaml:"remote"`
+	Releases []ReleaseSummary `json:"releases" yaml:"releases"`
+}
+
+// RenderText shows the current and latest versions, with the releases either
+// side of them
+func (s *Status) RenderText(output interfaces.OutputContext) {
+	if len(s.Releases) == 0 {
+		output.Println("No existing release branches found.")
+		output.Println("Remote:", s.Remote)
+		return
+	}
+
+	if s.Current != "" {
+		output.Println("Current version:", s.Current)
+	} else {
+		output.Println("Current version: (not on a release branch)")
+	}
+
+	output.Println("Latest version:", s.Latest)
+	output.Println("Remote:", s.Remote)
+	output.Println("Other versions:")
+
+	type ReleaseMetadata struct {
+		Label   string
+		Details []string
+		Tags    []string
+	}
+
+	releaseMetadata := []ReleaseMetadata{}
+	for _, release := range s.Releases {
+		tags := []string{}
+		if release.Latest {
+			tags = append(tags, "latest")
+		}
+
+		if release.Current {
+			tags = append(tags, "current")
+		}
+
+		// Release lines are shown with their latest patch
+		md := ReleaseMetadata{
+			Label:   release.Version,
+			Details: []string{},
+			Tags:    tags,
+		}
+
+		if release.Line != "" {
+			md.Label = release.Line
+			md.Details = append(md.Details, release.Version)
+		}
+
+		releaseMetadata = append(releaseMetadata, md)
+	}
+
+	maxI := len(releaseMetadata) - 1
+	skippedVersion := false
+	for i, md := range releaseMetadata {
+		// Display versions on either side of a tag, and tagged versions themselves
+		print := false
+		if i < maxI && len(releaseMetadata[i+1].Tags) > 0 {
+			print = true
+		} else if i > 0 && len(releaseMetadata[i-1].Tags) > 0 {
+			print = true
+		} else if len(md.Tags) > 0 {
+			print = true
+		}
+
+		if !print {
+			skippedVersion = true
+			continue
+		}
+
+		if skippedVersion {
+			output.Println(" - ...")
+			skippedVersion = false
+		}
+
+		details := append(md.Details, md.Tags...)
+		if len(details) > 0 {
+			output.Printf(" - %s (%s)\n", md.Label, strings.Join(details, ", "))
+		} else {
+			output.Printf(" - %s\n", md.Label)
+		}
+	}
+
+	if skippedVersion {
+		output.Println(" - ...")
+	}
+}
+
+// Description is where a commit sits relative to the releases: the releases
+// that contain it, the nearest release before it and how many commits after
+// that release it is
+type Description struct {
+	Commit       string   `json:"commit" yaml:"commit"`
+	ContainedIn  []string `json:"containedIn" yaml:"containedIn"`
+	Nearest      string   `json:"nearest,omitempty" yaml:"nearest,omitempty"`
+	Distance     int      `json:"distance" yaml:"distance"`
+	BuildVersion string   `json:"buildVersion" yaml:"buildVersion"`
+
+	// Show only the build version as text, for build scripts
+	VersionOnly bool `json:"-" yaml:"-"`
+}
+
+func (d *Description) RenderText(output interfaces.OutputContext) {
+	if d.VersionOnly {
+		output.Println(d.BuildVersion)
+		return
+	}
+
+	output.Println("Commit:", shortSHA(d.Commit))
+	if len(d.ContainedIn) > 0 {
+		output.Println("Contained in:", strings.Join(d.ContainedIn, ", "))
+	} else {
+		output.Println("Contained in: (no release)")
+	}
+
+	switch {
+	case d.Nearest == "":
+		output.Println("Nearest release: (none)")
+	case d.Distance == 0:
+		output.Printf("Nearest release: %s (this commit)\n", d.Nearest)
+	case d.Distance == 1:
+		output.Printf("Nearest release: %s (1 commit ago)\n", d.Nearest)
+	default:
+		output.Printf("Nearest release: %s (%d commits ago)\n", d.Nearest, d.Distance)
+	}
+
+	output.Println("Build version:", d.BuildVersion)
+}
+
+// Changelog is the commits of a release since the one before it, grouped
+// into sections
+type Changelog struct {
+	Version  string           `json:"version" yaml:"version"`
+	Previous string           `json:"previous,omitempty" yaml:"previous,omitempty"`
+	Date     string           `json:"date" yaml:"date"`
+	Groups   []ChangelogGroup `json:"groups" yaml:"groups"`
+
+	// The commits the changelog was made from, newest first, and the issue
+	// pattern they were read with, if one is set
+	commits      []interfaces.Commit
+	issuePattern *regexp.Regexp
+}
+
+type ChangelogGroup struct {
+	Title   string           `json:"title" yaml:"title"`
+	Entries []ChangelogEntry `json:"entries" yaml:"entries"`
+}
+
+type ChangelogEntry struct {
+	SHA         string   `json:"sha" yaml:"sha"`
+	Author      string   `json:"author,omitempty" yaml:"author,omitempty"`
+	Scope       string   `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Description string   `json:"description" yaml:"description"`
+	Breaking    bool     `json:"breaking,omitempty" yaml:"breaking,omitempty"`
+	Issues      []string `json:"issues,omitempty" yaml:"issues,omitempty"`
+}
+
+// add lists an entry under a group, starting the group if it's new
+func (c *Changelog) add(title string, entry ChangelogEntry) {
+	for i := range c.Groups {
+		if c.Groups[i].Title == title {
+			c.Groups[i].Entries = append(c.Groups[i].Entries, entry)
+			return
+		}
+	}
+
+	c.Groups = append(c.Groups, ChangelogGroup{Title: title, Entries: []ChangelogEntry{entry}})
+}
+
+// Markdown renders the changelog as a Keep a Changelog section
+func (c *Changelog) Markdown() string {
+	markdown := strings.Builder{}
+	fmt.Fprintf(&markdown, "## [%s] - %s\n", c.Version, c.Date)
+	for _, group := range c.Groups {
+		fmt.Fprintf(&markdown, "\n### %s\n\n", group.Title)
+		for _, entry := range group.Entries {
+			markdown.WriteString("- ")
+			if entry.Breaking {
+				markdown.WriteString("**BREAKING:** ")
+			}
+
+			if entry.Scope != "" {
+				fmt.Fprintf(&markdown, "**%s:** ", entry.Scope)
+			}
+
+			fmt.Fprintf(&markdown, "%s (%s)\n", entry.Description, shortSHA(entry.SHA))
+		}
+	}
+
+	return markdown.String()
+}
+
+func (c *Changelog) RenderText(output interfaces.OutputContext) {
+	output.Print(c.Markdown())
+}
+
+// ReleaseNotes is the notes for a release rendered from a template
+type ReleaseNotes struct {
+	Version  string `json:"version" yaml:"version"`
+	Previous string `json:"previous,omitempty" yaml:"previous,omitempty"`
+	Notes    string `json:"notes" yaml:"notes"`
+}
+
+func (n *ReleaseNotes) RenderText(output interfaces.OutputContext) {
+	output.Print(n.Notes)
+}
+
+// Log is the commits in one release that aren't in another, newest first
+type Log struct {
+	From    string      `json:"from" yaml:"from"`
+	To      string      `json:"to" yaml:"to"`
+	Commits []LogCommit `json:"commits" yaml:"commits"`
+}
+
+type LogCommit struct {
+	SHA     string `json:"sha" yaml:"sha"`
+	Author  string `json:"author" yaml:"author"`
+	Date    string `json:"date" yaml:"date"`
+	Subject string `json:"subject" yaml:"subject"`
+}
+
+func newLog(from *ReleaseInfo, to *ReleaseInfo, commits []interfaces.Commit) *Log {
+	log := &Log{
+		From:    from.Version.String(),
+		To:      to.Version.String(),
+		Commits: []LogCommit{},
+	}
+
+	for _, commit := range commits {
+		log.Commits = append(log.Commits, LogCommit{
+			SHA:     commit.SHA,
+			Author:  commit.Author,
+			Date:    commit.Date.Format(time.RFC3339),
+			Subject: commit.Subject,
+		})
+	}
+
+	return log
+}
+
+// RenderText lists the commits one per line, like git log --oneline with the
+// date and author
+func (l *Log) RenderText(output interfaces.OutputContext) {
+	if len(l.Commits) == 0 {
+		output.Printf("No commits in %s that aren't in %s.\n", l.To, l.From)
+		return
+	}
+
+	output.Printf("Commits in %s since %s:\n", l.To, l.From)
+	for _, commit := range l.Commits {
+		date, _ := time.Parse(time.RFC3339, commit.Date)
+		output.Printf("%s %s %s (%s)\n", shortSHA(commit.SHA), date.Format(time.DateOnly), commit.Subject, commit.Author)
+	}
+}
+
+// Diff is the changes from one release to another, as a patch or as the lines
+// changed in each file
+type Diff struct {
+	From  string     `json:"from" yaml:"from"`
+	To    string     `json:"to" yaml:"to"`
+	Patch string     `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Files []DiffFile `json:"files,omitempty" yaml:"files,omitempty"`
+}
+
+type DiffFile struct {
+	Path      string `json:"path" yaml:"path"`
+	OldPath   string `json:"oldPath,omitempty" yaml:"oldPath,omitempty"`
+	Additions int    `json:"additions" yaml:"additions"`
+	Deletions int    `json:"deletions" yaml:"deletions"`
+	Binary    bool   `json:"binary,omitempty" yaml:"binary,omitempty"`
+}
+
+func newDiffFiles(stats []interfaces.FileStat) []DiffFile {
+	files := []DiffFile{}
+	for _, stat := range stats {
+		files = append(files, DiffFile(stat))
+	}
+
+	return files
+}
+
+// RenderText shows the patch, or the files changed as git diff --stat does
+func (d *Diff) RenderText(output interfaces.OutputContext) {
+	if d.Patch == "" && len(d.Files) == 0 {
+		output.Printf("No changes from %s to %s.\n", d.From, d.To)
+		return
+	}
+
+	if d.Files == nil {
+		output.Print(d.Patch)
+		return
+	}
+
+	names := make([]string, len(d.Files))
+	width, most, additions, deletions := 0, 0, 0, 0
+	for i, file := range d.Files {
+		names[i] = file.Path
+		if file.OldPath != "" {
+			names[i] = file.OldPath + " => " + file.Path
+		}
+
+		width = max(width, len(names[i]))
+		most = max(most, file.Additions+file.Deletions)
+		additions += file.Additions
+		deletions += file.Deletions
+	}
+
+	// Bars are scaled down to fit when a file has many changed lines
+	const barWidth = 40
+	countWidth := len(strconv.Itoa(most))
+	for i, file := range d.Files {
+		if file.Binary {
+			output.Printf(" %-*s | Bin\n", width, names[i])
+			continue
+		}
+
+		plus, minus := scaleBar(file.Additions, most, barWidth), scaleBar(file.Deletions, most, barWidth)
+		output.Printf(" %-*s | %*d %s%s\n", width, names[i], countWidth, file.Additions+file.Deletions, strings.Repeat("+", plus), strings.Repeat("-", minus))
+	}
+
+	output.Printf(" %d %s changed, %d %s(+), %d %s(-)\n",
+		len(d.Files), plural(len(d.Files), "file", "files"),
+		additions, plural(additions, "insertion", "insertions"),
+		deletions, plural(deletions, "deletion", "deletions"))
+}
+
+// scaleBar scales a line count to a bar of at most width characters, where
+// most lines fill it, keeping at least one character for any changes
+func scaleBar(lines int, most int, width int) int {
+	if most <= width || lines == 0 {
+		return lines
+	}
+
+	return max(1, lines*width/most)
+}
+
+func plural(count int, singular string, pluralForm string) string {
+	if count == 1 {
+		return singular
+	}
+
+	return pluralForm
+}
+
+// OperationResult reports whether a command that changes releases succeeded.
+// Progress is written as it happens, so as text only the error is shown,
+// followed by the git commands planned by a dry run.
+type OperationResult struct {
+	Operation string      `json:"operation" yaml:"operation"`
+	Version   string      `json:"version,omitempty" yaml:"version,omitempty"`
+	Success   bool        `json:"success" yaml:"success"`
+	Error     string      `json:"error,omitempty" yaml:"error,omitempty"`
+	DryRun    *DryRunPlan `json:"dryRun,omitempty" yaml:"dryRun,omitempty"`
+	Status    *Status     `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+func NewOperationResult(operation string, version semver.Version, err error) *OperationResult {
+	result := &OperationResult{
+		Operation: operation,
+		Success:   err == nil,
+	}
+
+	if !version.IsZero() {
+		result.Version = version.String()
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+func (r *OperationResult) RenderText(output interfaces.OutputContext) {
+	if r.Error != "" {
+		output.Println(r.Error)
+	}
+
+	if r.DryRun != nil {
+		r.DryRun.RenderText(output)
+	}
+
+	if r.Status != nil {
+		r.Status.RenderText(output)
+	}
+}
+
+// DryRunPlan is the git commands a dry run would have run, in order
+type DryRunPlan struct {
+	Commands []string `json:"commands" yaml:"commands"`
+}
+
+func NewDryRunPlan(plan *PlanGitContext) *DryRunPlan {
+	commands := plan.Commands()
+	if commands == nil {
+		commands = []string{}
+	}
+
+	return &DryRunPlan{Commands: commands}
+}
+
+func (p *DryRunPlan) RenderText(output interfaces.OutputContext) {
+	if len(p.Commands) == 0 {
+		output.Println("Dry run: no git commands would be run")
+		return
+	}
+
+	output.Println("Dry run: the following git commands would be run")
+	for _, command := range p.Commands {
+		output.Printf("  %v\n", command)
+	}
+}
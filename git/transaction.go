@@ -0,0 +1,415 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"gitrel/interfaces"
+	"slices"
+	"strings"
+)
+
+const undoStateName = "undo"
+
+// journalStep is the inverse of one mutating git call, stored as data so a
+// completed operation can be undone by a later command
+type journalStep struct {
+	Action string   `json:"action"`
+	Args   []string `json:"args"`
+}
+
+const (
+	stepCheckout        = "checkout"
+	stepCreateBranchAt  = "createBranchAt"
+	stepDeleteBranch    = "deleteBranch"
+	stepResetBranch     = "resetBranch"
+	stepDeleteTag       = "deleteTag"
+	stepDeleteRemoteRef = "deleteRemoteRef"
+	stepForcePush       = "forcePush"
+)
+
+// String returns the git command equivalent to the step
+func (s journalStep) String() string {
+	switch s.Action {
+	case stepCheckout:
+		return "git checkout " + s.Args[0]
+	case stepCreateBranchAt:
+		return "git branch " + s.Args[0] + " " + s.Args[1]
+	case stepDeleteBranch:
+		return "git branch -D " + s.Args[0]
+	case stepResetBranch:
+		return "git reset --hard " + s.Args[0]
+	case stepDeleteTag:
+		return "git tag -d " + s.Args[0]
+	case stepDeleteRemoteRef:
+		return "git push " + s.Args[0] + " --delete " + s.Args[1]
+	case stepForcePush:
+		return "git push --force-with-lease " + s.Args[0] + " " + s.Args[1]
+	}
+
+	return s.Action + " " + strings.Join(s.Args, " ")
+}
+
+func (s journalStep) apply(gitCtx interfaces.GitContext) error {
+	switch s.Action {
+	case stepCheckout:
+		return gitCtx.CheckoutBranch(s.Args[0])
+	case stepCreateBranchAt:
+		return gitCtx.CreateBranchAt(s.Args[0], s.Args[1])
+	case stepDeleteBranch:
+		return gitCtx.DeleteBranch(s.Args[0])
+	case stepResetBranch:
+		return gitCtx.ResetBranch(s.Args[0])
+	case stepDeleteTag:
+		return gitCtx.DeleteTag(s.Args[0])
+	case stepDeleteRemoteRef:
+		return gitCtx.DeleteRemoteRef(s.Args[0], s.Args[1])
+	case stepForcePush:
+		return gitCtx.ForcePushBranch(s.Args[0], s.Args[1])
+	}
+
+	return fmt.Errorf("unknown journal step: %s", s.Action)
+}
+
+// journal is the record of a completed operation that `gitrel undo` reverses.
+// Branch and Head are where the operation left HEAD, so undo can refuse to run
+// once the repository has moved on.
+type journal struct {
+	Operation string        `json:"operation"`
+	Branch    string        `json:"branch"`
+	Head      string        `json:"head"`
+	Steps     []journalStep `json:"steps"`
+}
+
+// journalGitContext passes calls through to another GitContext, recording the
+// inverse of each mutating call that succeeds
+type journalGitContext struct {
+	interfaces.GitContext
+	steps []journalStep
+}
+
+func (j *journalGitContext) record(action string, args ...string) {
+	j.steps = append(j.steps, journalStep{Action: action, Args: args})
+}
+
+// currentRef returns the branch checked out, or the commit when HEAD is
+// detached, so it can be checked out again
+func (j *journalGitContext) currentRef() (string, error) {
+	branch, err := j.GitContext.GetCurrentBranch()
+	if err != nil || branch != "HEAD" {
+		return branch, err
+	}
+
+	return j.GitContext.ResolveCommit("HEAD")
+}
+
+func (j *journalGitContext) CheckoutBranch(branchName string) error {
+	previous, err := j.currentRef()
+	if err != nil {
+		return err
+	}
+
+	err = j.GitContext.CheckoutBranch(branchName)
+	if err != nil {
+		return err
+	}
+
+	j.record(stepCheckout, previous)
+	return nil
+}
+
+func (j *journalGitContext) SwitchToNewBranch(branchName string) error {
+	previous, err := j.currentRef()
+	if err != nil {
+		return err
+	}
+
+	err = j.GitContext.SwitchToNewBranch(branchName)
+	if err != nil {
+		return err
+	}
+
+	j.record(stepDeleteBranch, branchName)
+	j.record(stepCheckout, previous)
+	return nil
+}
+
+func (j *journalGitContext) SwitchBack() error {
+	previous, err := j.currentRef()
+	if err != nil {
+		return err
+	}
+
+	err = j.GitContext.SwitchBack()
+	if err != nil {
+		return err
+	}
+
+	j.record(stepCheckout, previous)
+	return nil
+}
+
+func (j *journalGitContext) CreateBranchAt(branchName string, commitish string) error {
+	err := j.GitContext.CreateBranchAt(branchName, commitish)
+	if err != nil {
+		return err
+	}
+
+	j.record(stepDeleteBranch, branchName)
+	return nil
+}
+
+func (j *journalGitContext) DeleteBranch(branchName string) error {
+	commit, err := j.GitContext.ResolveCommit("refs/heads/" + branchName)
+	if err != nil {
+		return err
+	}
+
+	err = j.GitContext.DeleteBranch(branchName)
+	if err != nil {
+		return err
+	}
+
+	j.record(stepCreateBranchAt, branchName, commit)
+	return nil
+}
+
+// PushBranch records deleting the remote branch if the push created it, or
+// force pushing its previous commit back if it moved it. The previous commit
+// comes from the remote tracking branch.
+func (j *journalGitContext) PushBranch(remote string, branchSpec string) error {
+	remoteBranch := branchSpec
+	if i := strings.LastIndex(branchSpec, ":"); i >= 0 {
+		remoteBranch = branchSpec[i+1:]
+	}
+	remoteBranch = strings.TrimPrefix(remoteBranch, "refs/heads/")
+
+	previous, err := j.GitContext.ResolveCommit("refs/remotes/" + remote + "/" + remoteBranch)
+	existed := err == nil
+
+	err = j.GitContext.PushBranch(remote, branchSpec)
+	if err != nil {
+		return err
+	}
+
+	if existed {
+		j.record(stepForcePush, remote, previous+":refs/heads/"+remoteBranch)
+	} else {
+		j.record(stepDeleteRemoteRef, remote, "refs/heads/"+remoteBranch)
+	}
+
+	return nil
+}
+
+// MergeBranch records resetting the branch to where it was even if the merge
+// fails, as a failed merge leaves conflicts in the working tree
+func (j *journalGitContext) MergeBranch(branchName string) error {
+	return j.resettable(func() error {
+		return j.GitContext.MergeBranch(branchName)
+	})
+}
+
+func (j *journalGitContext) ContinueMerge() error {
+	head, err := j.GitContext.ResolveCommit("HEAD")
+	if err != nil {
+		return err
+	}
+
+	err = j.GitContext.ContinueMerge()
+	if err != nil {
+		return err
+	}
+
+	j.record(stepResetBranch, head)
+	return nil
+}
+
+func (j *journalGitContext) CherryPick(commit string) error {
+	return j.resettable(func() error {
+		return j.GitContext.CherryPick(commit)
+	})
+}
+
+func (j *journalGitContext) resettable(call func() error) error {
+	head, err := j.GitContext.ResolveCommit("HEAD")
+	if err != nil {
+		return err
+	}
+
+	j.record(stepResetBranch, head)
+	return call()
+}
+
+func (j *journalGitContext) CreateTag(tagName string, commitish string, message string) error {
+	err := j.GitContext.CreateTag(tagName, commitish, message)
+	if err != nil {
+		return err
+	}
+
+	j.record(stepDeleteTag, tagName)
+	return nil
+}
+
+func (j *journalGitContext) PushTag(remote string, tagName string) error {
+	err := j.GitContext.PushTag(remote, tagName)
+	if err != nil {
+		return err
+	}
+
+	j.record(stepDeleteRemoteRef, remote, "refs/tags/"+tagName)
+	return nil
+}
+
+// transactionContext is a GitRelContext whose git calls are journaled
+type transactionContext struct {
+	interfaces.GitRelContext
+	git *journalGitContext
+}
+
+func (c *transactionContext) Git() interfaces.GitContext {
+	return c.git
+}
+
+// suspendedError marks an operation stopped part way for the user to continue
+// or abort, which must not be rolled back
+type suspendedError struct {
+	err error
+}
+
+func (e *suspendedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *suspendedError) Unwrap() error {
+	return e.err
+}
+
+// inTransaction runs an operation with its git calls journaled. If it fails,
+// the steps it completed are rolled back; if it succeeds, the journal is saved
+// so `gitrel undo` can reverse it. Operations started inside another one join
+// the outer transaction.
+func inTransaction(operation string, ctx interfaces.GitRelContext, run func(interfaces.GitRelContext) error) error {
+	return resumeTransaction(operation, nil, ctx, run)
+}
+
+// resumeTransaction is like inTransaction, for continuing an operation that
+// was suspended after taking the given steps. If the operation completes,
+// they are saved ahead of its own steps, so undo reverses the whole of it.
+func resumeTransaction(operation string, suspendedSteps []journalStep, ctx interfaces.GitRelContext, run func(interfaces.GitRelContext) error) error {
+	if _, ok := ctx.Git().(*journalGitContext); ok {
+		return run(ctx)
+	}
+
+	txCtx := &transactionContext{
+		GitRelContext: ctx,
+		git:           &journalGitContext{GitContext: ctx.Git()},
+	}
+
+	err := run(txCtx)
+
+	var suspended *suspendedError
+	if errors.As(err, &suspended) {
+		// Whatever was recorded for undo no longer matches the repository
+		return errors.Join(err, clearOperationState(undoStateName, ctx))
+	}
+
+	if err != nil {
+		if len(txCtx.git.steps) > 0 {
+			ctx.Output().Printf("Rolling back %v...\n", operation)
+		}
+
+		return errors.Join(err, rollback(txCtx.git.steps, ctx))
+	}
+
+	steps := append(slices.Clone(suspendedSteps), txCtx.git.steps...)
+	if len(steps) == 0 {
+		return nil
+	}
+
+	branch, err := ctx.Git().GetCurrentBranch()
+	if err != nil {
+		return err
+	}
+
+	head, err := ctx.Git().ResolveCommit("HEAD")
+	if err != nil {
+		return err
+	}
+
+	return saveOperationState(undoStateName, journal{
+		Operation: operation,
+		Branch:    branch,
+		Head:      head,
+		Steps:     steps,
+	}, ctx)
+}
+
+// journaledSteps returns the steps an operation has taken so far, to be kept
+// when it is suspended
+func journaledSteps(ctx interfaces.GitRelContext) []journalStep {
+	if j, ok := ctx.Git().(*journalGitContext); ok {
+		return slices.Clone(j.steps)
+	}
+
+	return nil
+}
+
+// rollback applies the inverse of each completed step, newest first. It keeps
+// going past steps that fail, so as much as possible is restored.
+func rollback(steps []journalStep, ctx interfaces.GitRelContext) error {
+	var errs []error
+	for i := len(steps) - 1; i >= 0; i-- {
+		ctx.Output().Printf("  %v\n", steps[i])
+		err := steps[i].apply(ctx.Git())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error rolling back '%s': %w", steps[i], err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Function to undo the last completed operation, as long as the repository
+// is still where that operation left it
+func UndoLastOperation(ctx interfaces.GitRelContext) error {
+	last := &journal{}
+	found, err := loadOperationState(undoStateName, last, ctx)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return newError(ErrNotFound, "nothing to undo")
+	}
+
+	hasUncommittedChanges, err := ctx.Git().HasUncommittedChanges()
+	if err != nil {
+		return err
+	}
+
+	if hasUncommittedChanges {
+		return newError(ErrDirtyWorktree, "you have uncommitted changes. please commit or stash them before undoing")
+	}
+
+	branch, err := ctx.Git().GetCurrentBranch()
+	if err != nil {
+		return err
+	}
+
+	head, err := ctx.Git().ResolveCommit("HEAD")
+	if err != nil {
+		return err
+	}
+
+	if branch != last.Branch || head != last.Head {
+		return fmt.Errorf("the repository has changed since '%s', so it cannot be undone", last.Operation)
+	}
+
+	ctx.Output().Printf("Undoing %v...\n", last.Operation)
+	err = rollback(last.Steps, ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx.Output().Println("Undone!")
+	return clearOperationState(undoStateName, ctx)
+}
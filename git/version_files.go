@@ -0,0 +1,48 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"gitrel/interfaces"
+	"gitrel/semver"
+	"strings"
+)
+
+// commitVersionFiles sets the version in each configured version file, as it
+// is at the commit the release branch was created from, and commits the files
+// that changed onto the branch, returning whether there was anything to commit
+func commitVersionFiles(branchName string, from string, version semver.Version, ctx interfaces.GitRelContext) (bool, error) {
+	files := map[string][]byte{}
+	for _, file := range ctx.Command().GetOptVersionFiles() {
+		contents, err := ctx.Git().ReadFile(from, file.Path)
+		if err != nil {
+			return false, err
+		}
+
+		updated, err := file.Update(contents, version.String())
+		if err != nil {
+			return false, err
+		}
+
+		if bytes.Equal(updated, contents) {
+			ctx.Output().Printf("Version in %s is already %s\n", file.Path, version)
+			continue
+		}
+
+		files[file.Path] = updated
+		reportChange(ctx, "Set version in %s to %s\n", "Would set version in %s to %s\n", file.Path, version)
+	}
+
+	if len(files) == 0 {
+		return false, nil
+	}
+
+	message := strings.ReplaceAll(ctx.Command().GetOptVersionCommitMessage(), "%v", version.String())
+	err := ctx.Git().CommitFiles(branchName, files, message)
+	if err != nil {
+		return false, fmt.Errorf("error committing version files: %w", err)
+	}
+
+	reportChange(ctx, "Committed version files to %s\n", "Would commit version files to %s\n", branchName)
+	return true, nil
+}
@@ -1,20 +1,47 @@
 package gitrel_test
 
+import (
+	"gitrel/conventional"
+	"gitrel/versionfile"
+)
+
 type TestCommandContext struct {
-	Fetch            bool
-	Remote           string
-	LocalBranchName  string
-	RemoteBranchName string
+	Fetch                bool
+	RemoteOnly           bool
+	AllRemotes           bool
+	Remote               string
+	LocalBranchName      string
+	RemoteBranchName     string
+	Mode                 string
+	Line                 string
+	Component            string
+	TagName              string
+	TagOnRelease         string
+	TagMessage           string
+	BumpTypes            map[string]string
+	ChangelogFile        string
+	ChangelogPattern     string
+	IssuePattern         string
+	NotesTemplate        string
+	VersionFiles         []versionfile.File
+	VersionCommitMessage string
 
 	fetched bool
 }
 
 func DefaultTestCommandContext() *TestCommandContext {
 	return &TestCommandContext{
-		Fetch:            false,
-		Remote:           "origin",
-		LocalBranchName:  "release/%v",
-		RemoteBranchName: "release/%v",
+		Fetch:                false,
+		Remote:               "origin",
+		LocalBranchName:      "release/%v",
+		RemoteBranchName:     "release/%v",
+		Mode:                 "branches",
+		Line:                 "patch",
+		TagName:              "v%v",
+		TagOnRelease:         "",
+		TagMessage:           "Release %v",
+		BumpTypes:            conventional.DefaultBumpRules(),
+		VersionCommitMessage: "Bump version to %v",
 
 		fetched: false,
 	}
@@ -24,6 +51,14 @@ func (c *TestCommandContext) GetOptFetch() bool {
 	return c.Fetch
 }
 
+func (c *TestCommandContext) GetOptRemoteOnly() bool {
+	return c.RemoteOnly
+}
+
+func (c *TestCommandContext) GetOptAllRemotes() bool {
+	return c.AllRemotes
+}
+
 func (c *TestCommandContext) GetOptRemote() string {
 	return c.Remote
 }
@@ -36,10 +71,62 @@ func (c *TestCommandContext) GetOptRemoteBranchName() string {
 	return c.RemoteBranchName
 }
 
+func (c *TestCommandContext) GetOptMode() string {
+	return c.Mode
+}
+
+func (c *TestCommandContext) GetOptLine() string {
+	return c.Line
+}
+
+func (c *TestCommandContext) GetOptComponent() string {
+	return c.Component
+}
+
+func (c *TestCommandContext) GetOptTagName() string {
+	return c.TagName
+}
+
+func (c *TestCommandContext) GetOptTagOnRelease() string {
+	return c.TagOnRelease
+}
+
+func (c *TestCommandContext) GetOptTagMessage() string {
+	return c.TagMessage
+}
+
+func (c *TestCommandContext) GetOptBumpTypes() map[string]string {
+	return c.BumpTypes
+}
+
+func (c *TestCommandContext) GetOptChangelogFile() string {
+	return c.ChangelogFile
+}
+
+func (c *TestCommandContext) GetOptChangelogPattern() string {
+	return c.ChangelogPattern
+}
+
+func (c *TestCommandContext) GetOptIssuePattern() string {
+	return c.IssuePattern
+}
+
+func (c *TestCommandContext) GetOptNotesTemplate() string {
+	return c.NotesTemplate
+}
+
+func (c *TestCommandContext) GetOptVersionFiles() []versionfile.File {
+	return c.VersionFiles
+}
+
+func (c *TestCommandContext) GetOptVersionCommitMessage() string {
+	return c.VersionCommitMessage
+}
+
 func (c *TestCommandContext) SetFetched(fetched bool) {
 	c.fetched = fetched
 }
 
 func (c *TestCommandContext) GetFetched() bool {
 	return c.fetched
-}
\ No newline at end of file
+}
@@ -2,16 +2,37 @@ package gitrel_test
 
 import (
 	"fmt"
+	"gitrel/interfaces"
+	"gitrel/utils"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 type TestGitContext struct {
 	Branches                []string
+	Tags                    []string
+	TagsAtHead              []string
 	CurrentBranch           string
+	DefaultBranch           string
+	ConflictingBranches     []string
+	RejectedPushes          []string
+	RemoteRefs              []string
+	Commits                 map[string]string
+	CommitDates             map[string]time.Time
+	RefDates                map[string]time.Time
+	Parents                 map[string][]string
+	Messages                map[string]string
+	Authors                 map[string]string
+	Diffs                   map[string]string
+	DiffStats               map[string][]interfaces.FileStat
+	Files                   map[string]string
+	CommittedFiles          map[string]string
+	State                   map[string][]byte
 	PreviousBranch          string
 	Remotes                 []string
+	UpstreamRemotes         map[string]string
 	SideEffects             []TestGitSideEffect
 	HasUncommittedChangesFl bool
 	testCtx                 *testing.T
@@ -35,9 +56,27 @@ func DefaultTestGitContext(t *testing.T) *TestGitContext {
 			"remotes/origin/release/1.1.1",
 			"remotes/origin/release/2.0.0",
 		},
+		Tags:                    []string{},
+		TagsAtHead:              []string{},
 		CurrentBranch:           "main",
+		DefaultBranch:           "main",
+		ConflictingBranches:     []string{},
+		RejectedPushes:          []string{},
+		RemoteRefs:              []string{},
+		Commits:                 map[string]string{},
+		CommitDates:             map[string]time.Time{},
+		RefDates:                map[string]time.Time{},
+		Parents:                 map[string][]string{},
+		Messages:                map[string]string{},
+		Authors:                 map[string]string{},
+		Diffs:                   map[string]string{},
+		DiffStats:               map[string][]interfaces.FileStat{},
+		Files:                   map[string]string{},
+		CommittedFiles:          map[string]string{},
+		State:                   map[string][]byte{},
 		PreviousBranch:          "",
 		Remotes:                 []string{"origin"},
+		UpstreamRemotes:         map[string]string{},
 		SideEffects:             []TestGitSideEffect{},
 		HasUncommittedChangesFl: false,
 		testCtx:                 t,
@@ -53,8 +92,45 @@ func (c *TestGitContext) FetchRemote(remote string) error {
 	return nil
 }
 
-func (c *TestGitContext) ListAllBranches() ([]string, error) {
-	return c.Branches, nil
+// ListAllBranches returns Branches as refs, with the commit and date for each
+// from Commits and CommitDates
+func (c *TestGitContext) ListAllBranches() ([]interfaces.Ref, error) {
+	refs := make([]interfaces.Ref, 0, len(c.Branches))
+	for _, branch := range c.Branches {
+		name := "refs/heads/" + branch
+		if strings.HasPrefix(branch, "remotes/") {
+			name = "refs/" + branch
+		}
+
+		refs = append(refs, interfaces.Ref{
+			Name:      name,
+			ShortName: branch,
+			SHA:       c.Commits[branch],
+			Date:      c.CommitDates[branch],
+		})
+	}
+
+	return refs, nil
+}
+
+// ListRemoteRefs returns RemoteRefs, which are full ref names on the remote, as
+// refs with the commit for each from Commits
+func (c *TestGitContext) ListRemoteRefs(remote string) ([]interfaces.Ref, error) {
+	refs := make([]interfaces.Ref, 0, len(c.RemoteRefs))
+	for _, name := range c.RemoteRefs {
+		shortName := strings.TrimPrefix(name, "refs/tags/")
+		if branch, ok := strings.CutPrefix(name, "refs/heads/"); ok {
+			shortName = "remotes/" + remote + "/" + branch
+		}
+
+		refs = append(refs, interfaces.Ref{
+			Name:      name,
+			ShortName: shortName,
+			SHA:       c.Commits[name],
+		})
+	}
+
+	return refs, nil
 }
 
 func (c *TestGitContext) BranchExists(branchName string) (bool, error) {
@@ -62,8 +138,8 @@ func (c *TestGitContext) BranchExists(branchName string) (bool, error) {
 }
 
 func (c *TestGitContext) CheckoutBranch(branchName string) error {
-	if !slices.Contains(c.Branches, branchName) {
-		return fmt.Errorf("branch %s does not exist", branchName)
+	if !slices.Contains(c.Branches, branchName) && !slices.Contains(c.Tags, branchName) {
+		return utils.WithKind(interfaces.ErrNotFound, fmt.Errorf("branch %s does not exist", branchName))
 	}
 
 	c.SideEffects = append(c.SideEffects, EffectCheckoutBranch(branchName))
@@ -74,16 +150,28 @@ func (c *TestGitContext) CheckoutBranch(branchName string) error {
 
 func (c *TestGitContext) CreateBranchAt(branchName string, commitish string) error {
 	c.SideEffects = append(c.SideEffects, EffectCreateBranchAt(branchName, commitish))
+	c.Branches = append(c.Branches, branchName)
+	return nil
+}
+
+func (c *TestGitContext) DeleteBranch(branchName string) error {
+	if !slices.Contains(c.Branches, branchName) {
+		return utils.WithKind(interfaces.ErrNotFound, fmt.Errorf("branch %s does not exist", branchName))
+	}
+
+	c.SideEffects = append(c.SideEffects, EffectDeleteBranch(branchName))
+	c.Branches = slices.DeleteFunc(c.Branches, func(b string) bool { return b == branchName })
 	return nil
 }
 
 func (c *TestGitContext) SwitchToNewBranch(branchName string) error {
 	if slices.Contains(c.Branches, branchName) {
-		return fmt.Errorf("branch %s already exists", branchName)
+		return utils.WithKind(interfaces.ErrAlreadyExists, fmt.Errorf("branch %s already exists", branchName))
 	}
 
 	c.SideEffects = append(c.SideEffects, EffectCreateBranch(branchName))
 	c.SideEffects = append(c.SideEffects, EffectCheckoutBranch(branchName))
+	c.Branches = append(c.Branches, branchName)
 	c.PreviousBranch = c.CurrentBranch
 	c.CurrentBranch = branchName
 	return nil
@@ -99,10 +187,25 @@ func (c *TestGitContext) SwitchBack() error {
 }
 
 func (c *TestGitContext) PushBranch(remote string, branchSpec string) error {
+	parts := strings.Split(branchSpec, ":")
+	if slices.Contains(c.RejectedPushes, parts[len(parts)-1]) {
+		return utils.WithKind(interfaces.ErrPushRejected, fmt.Errorf("push of %s to %s rejected", branchSpec, remote))
+	}
+
 	c.SideEffects = append(c.SideEffects, EffectPushBranch(remote, branchSpec))
 	return nil
 }
 
+func (c *TestGitContext) ForcePushBranch(remote string, branchSpec string) error {
+	c.SideEffects = append(c.SideEffects, EffectForcePushBranch(remote, branchSpec))
+	return nil
+}
+
+func (c *TestGitContext) DeleteRemoteRef(remote string, ref string) error {
+	c.SideEffects = append(c.SideEffects, EffectDeleteRemoteRef(remote, ref))
+	return nil
+}
+
 func (c *TestGitContext) GetCurrentBranch() (string, error) {
 	return c.CurrentBranch, nil
 }
@@ -111,8 +214,282 @@ func (c *TestGitContext) ListRemotes() ([]string, error) {
 	return c.Remotes, nil
 }
 
+func (c *TestGitContext) GetUpstreamRemote(branchName string) (string, error) {
+	return c.UpstreamRemotes[branchName], nil
+}
+
 func (c *TestGitContext) MergeBranch(branchName string) error {
 	c.SideEffects = append(c.SideEffects, EffectMergeBranch(branchName))
+
+	if slices.Contains(c.ConflictingBranches, c.CurrentBranch) {
+		return utils.WithKind(interfaces.ErrMergeConflict, fmt.Errorf("CONFLICT merging %s into %s", branchName, c.CurrentBranch))
+	}
+
+	return nil
+}
+
+func (c *TestGitContext) ContinueMerge() error {
+	c.SideEffects = append(c.SideEffects, EffectContinueMerge())
+	return nil
+}
+
+func (c *TestGitContext) AbortMerge() error {
+	c.SideEffects = append(c.SideEffects, EffectAbortMerge())
+	return nil
+}
+
+func (c *TestGitContext) GetDefaultBranch(remote string) (string, error) {
+	return c.DefaultBranch, nil
+}
+
+func (c *TestGitContext) ListTags() ([]string, error) {
+	return c.Tags, nil
+}
+
+func (c *TestGitContext) ListTagsAt(commitish string) ([]string, error) {
+	return c.TagsAtHead, nil
+}
+
+func (c *TestGitContext) CreateTag(tagName string, commitish string, message string) error {
+	if slices.Contains(c.Tags, tagName) {
+		return utils.WithKind(interfaces.ErrAlreadyExists, fmt.Errorf("tag %s already exists", tagName))
+	}
+
+	c.SideEffects = append(c.SideEffects, EffectCreateTag(tagName, commitish))
+	return nil
+}
+
+func (c *TestGitContext) PushTag(remote string, tagName string) error {
+	if slices.Contains(c.RejectedPushes, tagName) {
+		return utils.WithKind(interfaces.ErrPushRejected, fmt.Errorf("push of %s to %s rejected", tagName, remote))
+	}
+
+	c.SideEffects = append(c.SideEffects, EffectPushTag(remote, tagName))
+	return nil
+}
+
+func (c *TestGitContext) DeleteTag(tagName string) error {
+	c.SideEffects = append(c.SideEffects, EffectDeleteTag(tagName))
+	return nil
+}
+
+func (c *TestGitContext) ResetBranch(commit string) error {
+	c.SideEffects = append(c.SideEffects, EffectResetBranch(commit))
+	return nil
+}
+
+// ResolveCommit looks the commit-ish up in Commits. Otherwise HEAD resolves
+// to the current branch, known branches and tags to themselves, and hex
+// strings are taken to be commit hashes.
+func (c *TestGitContext) ResolveCommit(commitish string) (string, error) {
+	if commit, ok := c.Commits[commitish]; ok {
+		return commit, nil
+	}
+
+	if commitish == "HEAD" {
+		return c.CurrentBranch, nil
+	}
+
+	name := strings.TrimPrefix(strings.TrimPrefix(commitish, "refs/"), "heads/")
+	name = strings.TrimPrefix(name, "tags/")
+	if slices.Contains(c.Branches, name) || slices.Contains(c.Tags, name) {
+		return name, nil
+	}
+
+	if strings.Trim(commitish, "0123456789abcdef") == "" {
+		return commitish, nil
+	}
+
+	return "", utils.WithKind(interfaces.ErrNotFound, fmt.Errorf("not a valid commit: %s", commitish))
+}
+
+// IsAncestor walks Parents, which maps each resolved commit to its parents
+func (c *TestGitContext) IsAncestor(ancestor string, commitish string) (bool, error) {
+	ancestorCommit, err := c.ResolveCommit(ancestor)
+	if err != nil {
+		return false, err
+	}
+
+	commits, err := c.ancestors(commitish)
+	if err != nil {
+		return false, err
+	}
+
+	return commits[ancestorCommit], nil
+}
+
+func (c *TestGitContext) CountCommits(from string, to string) (int, error) {
+	commits, err := c.ancestors(to)
+	if err != nil {
+		return 0, err
+	}
+
+	excluded := map[string]bool{}
+	if from != "" {
+		excluded, err = c.ancestors(from)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	count := 0
+	for commit := range commits {
+		if !excluded[commit] {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// RefDate returns the date in RefDates, or else the date in CommitDates of the
+// commit the ref points to
+func (c *TestGitContext) RefDate(refName string) (time.Time, error) {
+	if date, ok := c.RefDates[refName]; ok {
+		return date, nil
+	}
+
+	name := strings.TrimPrefix(interfaces.ShortRefName(refName), "tags/")
+	if commit, ok := c.Commits[name]; ok {
+		name = commit
+	}
+
+	return c.CommitDates[name], nil
+}
+
+// ListCommits walks Parents from to, newest first, with each commit's message,
+// author and date from Messages, Authors and CommitDates
+func (c *TestGitContext) ListCommits(from string, to string) ([]interfaces.Commit, error) {
+	excluded := map[string]bool{}
+	if from != "" {
+		var err error
+		excluded, err = c.ancestors(from)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	commit, err := c.ResolveCommit(to)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := []interfaces.Commit{}
+	seen := map[string]bool{}
+	pending := []string{commit}
+	for len(pending) > 0 {
+		commit, pending = pending[0], pending[1:]
+		if seen[commit] || excluded[commit] {
+			continue
+		}
+
+		seen[commit] = true
+		subject, body, _ := strings.Cut(c.Messages[commit], "\n\n")
+		commits = append(commits, interfaces.Commit{
+			SHA:     commit,
+			Author:  c.Authors[commit],
+			Date:    c.CommitDates[commit],
+			Subject: subject,
+			Body:    body,
+		})
+		pending = append(pending, c.Parents[commit]...)
+	}
+
+	return commits, nil
+}
+
+// ancestors returns every commit reachable from a commit-ish through Parents,
+// including itself
+func (c *TestGitContext) ancestors(commitish string) (map[string]bool, error) {
+	commit, err := c.ResolveCommit(commitish)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	pending := []string{commit}
+	for len(pending) > 0 {
+		commit, pending = pending[len(pending)-1], pending[:len(pending)-1]
+		if seen[commit] {
+			continue
+		}
+
+		seen[commit] = true
+		pending = append(pending, c.Parents[commit]...)
+	}
+
+	return seen, nil
+}
+
+// Diff returns the patch in Diffs under from..to
+func (c *TestGitContext) Diff(from string, to string) (string, error) {
+	return c.Diffs[from+".."+to], nil
+}
+
+// DiffStat returns the files in DiffStats under from..to
+func (c *TestGitContext) DiffStat(from string, to string) ([]interfaces.FileStat, error) {
+	stats, ok := c.DiffStats[from+".."+to]
+	if !ok {
+		return []interfaces.FileStat{}, nil
+	}
+
+	return stats, nil
+}
+
+// ReadFile returns the contents of a file from Files, which holds the same
+// contents at every commit
+func (c *TestGitContext) ReadFile(commitish string, path string) ([]byte, error) {
+	contents, ok := c.Files[path]
+	if !ok {
+		return nil, utils.WithKind(interfaces.ErrNotFound, fmt.Errorf("%s not found at %s", path, commitish))
+	}
+
+	return []byte(contents), nil
+}
+
+func (c *TestGitContext) CommitFiles(branchName string, files map[string][]byte, message string) error {
+	paths := []string{}
+	for path, contents := range files {
+		paths = append(paths, path)
+		c.CommittedFiles[path] = string(contents)
+	}
+
+	slices.Sort(paths)
+	c.SideEffects = append(c.SideEffects, EffectCommitFiles(branchName, message, paths...))
+	return nil
+}
+
+func (c *TestGitContext) CherryPick(commit string) error {
+	c.SideEffects = append(c.SideEffects, EffectCherryPick(commit))
+
+	if slices.Contains(c.ConflictingBranches, c.CurrentBranch) {
+		return utils.WithKind(interfaces.ErrMergeConflict, fmt.Errorf("CONFLICT cherry-picking %s onto %s", commit, c.CurrentBranch))
+	}
+
+	return nil
+}
+
+func (c *TestGitContext) ContinueCherryPick() error {
+	c.SideEffects = append(c.SideEffects, EffectContinueCherryPick())
+	return nil
+}
+
+func (c *TestGitContext) AbortCherryPick() error {
+	c.SideEffects = append(c.SideEffects, EffectAbortCherryPick())
+	return nil
+}
+
+func (c *TestGitContext) ReadState(name string) ([]byte, error) {
+	return c.State[name], nil
+}
+
+func (c *TestGitContext) WriteState(name string, data []byte) error {
+	c.State[name] = data
+	return nil
+}
+
+func (c *TestGitContext) RemoveState(name string) error {
+	delete(c.State, name)
 	return nil
 }
 
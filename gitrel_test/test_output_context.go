@@ -2,18 +2,21 @@ package gitrel_test
 
 import (
 	"fmt"
+	"gitrel/interfaces"
 	"strings"
 	"testing"
 )
 
 type TestOutputContext struct {
 	Output  string
+	Results []interfaces.Result
 	testCtx *testing.T
 }
 
 func DefaultTestOutputContext(t *testing.T) *TestOutputContext {
 	return &TestOutputContext{
 		Output:  "",
+		Results: []interfaces.Result{},
 		testCtx: t,
 	}
 }
@@ -30,6 +33,13 @@ func (c *TestOutputContext) Printf(format string, args ...interface{}) {
 	c.Output += fmt.Sprintf(format, args...)
 }
 
+// Emit records the result and renders it as text, as text is the default
+// output format
+func (c *TestOutputContext) Emit(result interfaces.Result) {
+	c.Results = append(c.Results, result)
+	result.RenderText(c)
+}
+
 func (c *TestOutputContext) AssertOutput(expectedOutput string) {
 	c.testCtx.Helper()
 	if c.Output != expectedOutput {
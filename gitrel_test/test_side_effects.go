@@ -24,6 +24,62 @@ func EffectMergeBranch(branch string) TestGitSideEffect {
 	return TestGitSideEffect("merge " + branch)
 }
 
+func EffectCreateTag(tag string, commitish string) TestGitSideEffect {
+	if commitish == "" {
+		return TestGitSideEffect("create tag " + tag)
+	}
+
+	return TestGitSideEffect("create tag " + tag + " at " + commitish)
+}
+
+func EffectPushTag(remote string, tag string) TestGitSideEffect {
+	return TestGitSideEffect("push tag " + remote + " " + tag)
+}
+
+func EffectDeleteBranch(branch string) TestGitSideEffect {
+	return TestGitSideEffect("delete branch " + branch)
+}
+
+func EffectContinueMerge() TestGitSideEffect {
+	return TestGitSideEffect("continue merge")
+}
+
+func EffectAbortMerge() TestGitSideEffect {
+	return TestGitSideEffect("abort merge")
+}
+
+func EffectDeleteTag(tag string) TestGitSideEffect {
+	return TestGitSideEffect("delete tag " + tag)
+}
+
+func EffectDeleteRemoteRef(remote string, ref string) TestGitSideEffect {
+	return TestGitSideEffect("delete remote " + remote + " " + ref)
+}
+
+func EffectForcePushBranch(remote string, branchSpec string) TestGitSideEffect {
+	return TestGitSideEffect("force push " + remote + " " + branchSpec)
+}
+
+func EffectResetBranch(commit string) TestGitSideEffect {
+	return TestGitSideEffect("reset " + commit)
+}
+
+func EffectCommitFiles(branch string, message string, paths ...string) TestGitSideEffect {
+	return TestGitSideEffect("commit " + strings.Join(paths, " ") + " to " + branch + ": " + message)
+}
+
+func EffectCherryPick(commit string) TestGitSideEffect {
+	return TestGitSideEffect("cherry-pick " + commit)
+}
+
+func EffectContinueCherryPick() TestGitSideEffect {
+	return TestGitSideEffect("continue cherry-pick")
+}
+
+func EffectAbortCherryPick() TestGitSideEffect {
+	return TestGitSideEffect("abort cherry-pick")
+}
+
 func EffectPushBranch(remote string, branch string) TestGitSideEffect {
 	parts := strings.Split(branch, ":")
 	if len(parts) == 1 {
@@ -7,17 +7,37 @@ func GetStdOutIgnoreSideEffects(ctx *TestGitRelContext, call func(*TestGitRelCon
 		GitContext: &TestGitContext{
 			testCtx:        ctx.GitContext.testCtx,
 			Branches:       ctx.GitContext.Branches,
+			Tags:           ctx.GitContext.Tags,
+			RemoteRefs:     ctx.GitContext.RemoteRefs,
+			Commits:        ctx.GitContext.Commits,
+			TagsAtHead:     ctx.GitContext.TagsAtHead,
 			Remotes:        ctx.GitContext.Remotes,
 			CurrentBranch:  ctx.GitContext.CurrentBranch,
+			DefaultBranch:  ctx.GitContext.DefaultBranch,
 			PreviousBranch: ctx.GitContext.PreviousBranch,
 			SideEffects:    []TestGitSideEffect{},
 		},
 		CommandContext: &TestCommandContext{
-			Fetch:            ctx.CommandContext.fetched,
-			Remote:           ctx.CommandContext.Remote,
-			LocalBranchName:  ctx.CommandContext.LocalBranchName,
-			RemoteBranchName: ctx.CommandContext.RemoteBranchName,
-			fetched:          ctx.CommandContext.fetched,
+			Fetch:                ctx.CommandContext.fetched,
+			RemoteOnly:           ctx.CommandContext.RemoteOnly,
+			AllRemotes:           ctx.CommandContext.AllRemotes,
+			Remote:               ctx.CommandContext.Remote,
+			LocalBranchName:      ctx.CommandContext.LocalBranchName,
+			RemoteBranchName:     ctx.CommandContext.RemoteBranchName,
+			Mode:                 ctx.CommandContext.Mode,
+			Line:                 ctx.CommandContext.Line,
+			Component:            ctx.CommandContext.Component,
+			TagName:              ctx.CommandContext.TagName,
+			TagOnRelease:         ctx.CommandContext.TagOnRelease,
+			TagMessage:           ctx.CommandContext.TagMessage,
+			BumpTypes:            ctx.CommandContext.BumpTypes,
+			ChangelogFile:        ctx.CommandContext.ChangelogFile,
+			ChangelogPattern:     ctx.CommandContext.ChangelogPattern,
+			IssuePattern:         ctx.CommandContext.IssuePattern,
+			NotesTemplate:        ctx.CommandContext.NotesTemplate,
+			VersionFiles:         ctx.CommandContext.VersionFiles,
+			VersionCommitMessage: ctx.CommandContext.VersionCommitMessage,
+			fetched:              ctx.CommandContext.fetched,
 		},
 		OutputContext: &TestOutputContext{
 			Output: "",
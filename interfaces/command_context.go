@@ -1,10 +1,27 @@
 package interfaces
 
+import "gitrel/versionfile"
+
 type CommandContext interface {
 	GetOptFetch() bool
+	GetOptRemoteOnly() bool
+	GetOptAllRemotes() bool
 	GetOptRemote() string
 	GetOptLocalBranchName() string
 	GetOptRemoteBranchName() string
+	GetOptMode() string
+	GetOptLine() string
+	GetOptComponent() string
+	GetOptTagName() string
+	GetOptTagOnRelease() string
+	GetOptTagMessage() string
+	GetOptBumpTypes() map[string]string
+	GetOptChangelogFile() string
+	GetOptChangelogPattern() string
+	GetOptIssuePattern() string
+	GetOptNotesTemplate() string
+	GetOptVersionFiles() []versionfile.File
+	GetOptVersionCommitMessage() string
 
 	SetFetched(fetched bool)
 	GetFetched() bool
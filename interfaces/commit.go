@@ -0,0 +1,13 @@
+package interfaces
+
+import "time"
+
+// Commit is a commit as git log shows it
+type Commit struct {
+	SHA         string
+	Author      string
+	AuthorEmail string
+	Date        time.Time // Author date
+	Subject     string    // First line of the message
+	Body        string    // Rest of the message, without the blank line after the subject
+}
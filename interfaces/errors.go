@@ -0,0 +1,14 @@
+package interfaces
+
+import "errors"
+
+// Kinds of failure that GitContext implementations and gitrel operations
+// report, so callers can tell them apart with errors.Is
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrAlreadyExists   = errors.New("already exists")
+	ErrDirtyWorktree   = errors.New("uncommitted changes")
+	ErrMergeConflict   = errors.New("merge conflict")
+	ErrPushRejected    = errors.New("push rejected")
+	ErrAmbiguousRemote = errors.New("ambiguous remote")
+)
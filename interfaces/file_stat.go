@@ -0,0 +1,11 @@
+package interfaces
+
+// FileStat is how much a file changed between two commits, as git diff
+// --numstat counts it
+type FileStat struct {
+	Path      string
+	OldPath   string // The path before the file was renamed, if it was
+	Additions int
+	Deletions int
+	Binary    bool // Binary files have no line counts
+}
@@ -1,16 +1,47 @@
 package interfaces
 
+import "time"
+
 type GitContext interface {
 	FetchRemote(remote string) error
-	ListAllBranches() ([]string, error)
+	ListAllBranches() ([]Ref, error)
+	ListRemoteRefs(remote string) ([]Ref, error)
 	BranchExists(branchName string) (bool, error)
 	CheckoutBranch(branchName string) error
 	SwitchToNewBranch(branchName string) error
 	CreateBranchAt(branchName string, commitish string) error
+	DeleteBranch(branchName string) error
 	SwitchBack() error
 	PushBranch(remote string, branchSpec string) error
 	GetCurrentBranch() (string, error)
 	ListRemotes() ([]string, error)
+	GetUpstreamRemote(branchName string) (string, error)
 	MergeBranch(branchName string) error
+	ContinueMerge() error
+	AbortMerge() error
+	GetDefaultBranch(remote string) (string, error)
 	HasUncommittedChanges() (bool, error)
+	ListTags() ([]string, error)
+	ListTagsAt(commitish string) ([]string, error)
+	CreateTag(tagName string, commitish string, message string) error
+	PushTag(remote string, tagName string) error
+	DeleteTag(tagName string) error
+	DeleteRemoteRef(remote string, ref string) error
+	ForcePushBranch(remote string, branchSpec string) error
+	ResetBranch(commit string) error
+	ResolveCommit(commitish string) (string, error)
+	IsAncestor(ancestor string, commitish string) (bool, error)
+	CountCommits(from string, to string) (int, error)
+	ListCommits(from string, to string) ([]Commit, error)
+	RefDate(refName string) (time.Time, error)
+	Diff(from string, to string) (string, error)
+	DiffStat(from string, to string) ([]FileStat, error)
+	ReadFile(commitish string, path string) ([]byte, error)
+	CommitFiles(branchName string, files map[string][]byte, message string) error
+	CherryPick(commit string) error
+	ContinueCherryPick() error
+	AbortCherryPick() error
+	ReadState(name string) ([]byte, error)
+	WriteState(name string, data []byte) error
+	RemoveState(name string) error
 }
\ No newline at end of file
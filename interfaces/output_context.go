@@ -4,4 +4,11 @@ type OutputContext interface {
 	Print(args ...interface{})
 	Println(args ...interface{})
 	Printf(format string, args ...interface{})
+	Emit(result Result)
+}
+
+// Result is a structured command result, written as a document in the
+// selected output format or rendered as text
+type Result interface {
+	RenderText(output OutputContext)
 }
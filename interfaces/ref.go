@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"strings"
+	"time"
+)
+
+// Ref is a branch or tag, with the commit at its tip and, for a local branch,
+// how it compares to its upstream
+type Ref struct {
+	Name      string    // Full ref name, e.g. refs/remotes/origin/main
+	ShortName string    // Name as git branch -a or git tag shows it, e.g. remotes/origin/main
+	SHA       string    // Commit at the tip of the branch
+	Date      time.Time // Committer date of that commit
+	Upstream  string    // Upstream of a local branch, e.g. origin/main
+	Ahead     int       // Commits on the branch that aren't on its upstream
+	Behind    int       // Commits on the upstream that aren't on the branch
+}
+
+// ShortRefName returns the name git branch -a shows for a branch ref, which is
+// the name with refs/heads/ or refs/ removed
+func ShortRefName(name string) string {
+	if short, ok := strings.CutPrefix(name, "refs/heads/"); ok {
+		return short
+	}
+
+	return strings.TrimPrefix(name, "refs/")
+}
@@ -0,0 +1,318 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Fields holds the values of the placeholders in a template
+type Fields struct {
+	Version    string
+	Major      string
+	Minor      string
+	Patch      string
+	Prerelease string
+	Build      string
+	Component  string
+	Sequence   string
+}
+
+// Template is a compiled branch or tag name template. Placeholders are
+// written as {version}, {major}, {minor}, {patch}, {prerelease}, {build} and
+// {component}. The legacy %v placeholder is an alias for {version}; any other
+// text, including a lone %, is matched literally. A template with {patch} but
+// without {prerelease} or {build} keeps them after {patch}, as in a version,
+// so prereleases and builds still get names of their own.
+type Template struct {
+	source string
+	parts  []part
+	re     *regexp.Regexp
+	names  []string
+}
+
+type part struct {
+	literal     string
+	placeholder string
+	// qualifiers are the placeholders the template leaves out that follow
+	// this one, with their separator
+	qualifiers []string
+}
+
+var qualifierSeparators = map[string]string{
+	"prerelease": "-",
+	"build":      "+",
+}
+
+var placeholderPatterns = map[string]string{
+	"version":    `[0-9]+(?:\.[0-9]+)*(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?`,
+	"major":      `[0-9]+`,
+	"minor":      `[0-9]+`,
+	"patch":      `[0-9]+`,
+	"prerelease": `[0-9A-Za-z.-]+`,
+	"build":      `[0-9A-Za-z.-]+`,
+	"component":  `[0-9A-Za-z._-]+`,
+	"sequence":   `[0-9]+`,
+}
+
+// Compile parses a template, returning an error for unknown or unterminated
+// placeholders
+func Compile(source string) (*Template, error) {
+	return compile(source, false)
+}
+
+// CompileTag is like Compile, but also accepts %n for a sequence number, as
+// used by the tags and messages of tagOnRelease
+func CompileTag(source string) (*Template, error) {
+	return compile(source, true)
+}
+
+func compile(source string, sequence bool) (*Template, error) {
+	t := &Template{source: source}
+
+	literal := strings.Builder{}
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			t.parts = append(t.parts, part{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(source); i++ {
+		switch {
+		case strings.HasPrefix(source[i:], "%v"):
+			flushLiteral()
+			t.parts = append(t.parts, part{placeholder: "version"})
+			i++
+		case sequence && strings.HasPrefix(source[i:], "%n"):
+			flushLiteral()
+			t.parts = append(t.parts, part{placeholder: "sequence"})
+			i++
+		case source[i] == '{':
+			end := strings.IndexByte(source[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated placeholder in template '%s'", source)
+			}
+
+			name := source[i+1 : i+end]
+			if _, ok := placeholderPatterns[name]; !ok || name == "sequence" {
+				return nil, fmt.Errorf("unknown placeholder {%s} in template '%s'", name, source)
+			}
+
+			flushLiteral()
+			t.parts = append(t.parts, part{placeholder: name})
+			i += end
+		default:
+			literal.WriteByte(source[i])
+		}
+	}
+
+	flushLiteral()
+	t.addQualifiers()
+
+	expr := strings.Builder{}
+	expr.WriteString("^")
+	for _, p := range t.parts {
+		if p.placeholder == "" {
+			expr.WriteString(regexp.QuoteMeta(p.literal))
+			continue
+		}
+
+		expr.WriteString("(" + placeholderPatterns[p.placeholder] + ")")
+		t.names = append(t.names, p.placeholder)
+
+		for _, qualifier := range p.qualifiers {
+			expr.WriteString("(?:" + regexp.QuoteMeta(qualifierSeparators[qualifier]) + "(" + placeholderPatterns[qualifier] + "))?")
+			t.names = append(t.names, qualifier)
+		}
+	}
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil, fmt.Errorf("error compiling template '%s': %w", source, err)
+	}
+
+	t.re = re
+	return t, nil
+}
+
+// addQualifiers attaches the prerelease and build the template leaves out to
+// {patch}, or the build to {prerelease} if that comes last
+func (t *Template) addQualifiers() {
+	if t.Has("version") || !t.Has("patch") {
+		return
+	}
+
+	last := -1
+	for i, p := range t.parts {
+		if p.placeholder == "patch" || p.placeholder == "prerelease" {
+			last = i
+		}
+	}
+
+	for _, qualifier := range []string{"prerelease", "build"} {
+		if t.Has(qualifier) {
+			continue
+		}
+
+		i := last
+		if qualifier == "prerelease" {
+			i = t.index("patch")
+		}
+
+		t.parts[i].qualifiers = append(t.parts[i].qualifiers, qualifier)
+	}
+}
+
+// MustCompile is like Compile but panics if the template is invalid
+func MustCompile(source string) *Template {
+	t, err := Compile(source)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+func (t *Template) String() string {
+	return t.source
+}
+
+// Has is true if the template contains the named placeholder
+func (t *Template) Has(name string) bool {
+	for _, p := range t.parts {
+		if p.placeholder == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// index returns the position of the first part with the named placeholder
+func (t *Template) index(name string) int {
+	for i, p := range t.parts {
+		if p.placeholder == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Render substitutes the fields into the template
+func (t *Template) Render(fields Fields) string {
+	out := strings.Builder{}
+	for _, p := range t.parts {
+		if p.placeholder == "" {
+			out.WriteString(p.literal)
+			continue
+		}
+
+		out.WriteString(fields.get(p.placeholder))
+		for _, qualifier := range p.qualifiers {
+			if value := fields.get(qualifier); value != "" {
+				out.WriteString(qualifierSeparators[qualifier] + value)
+			}
+		}
+	}
+
+	return out.String()
+}
+
+// Parse matches a whole name against the template, returning the placeholder
+// values. If a placeholder appears more than once, every occurrence must
+// match the same value.
+func (t *Template) Parse(name string) (Fields, bool) {
+	match := t.re.FindStringSubmatch(name)
+	if match == nil {
+		return Fields{}, false
+	}
+
+	fields := Fields{}
+	seen := map[string]bool{}
+	for i, placeholder := range t.names {
+		value := match[i+1]
+		if seen[placeholder] && fields.get(placeholder) != value {
+			return Fields{}, false
+		}
+
+		fields.set(placeholder, value)
+		seen[placeholder] = true
+	}
+
+	return fields, true
+}
+
+func (f *Fields) get(name string) string {
+	switch name {
+	case "version":
+		return f.Version
+	case "major":
+		return f.Major
+	case "minor":
+		return f.Minor
+	case "patch":
+		return f.Patch
+	case "prerelease":
+		return f.Prerelease
+	case "build":
+		return f.Build
+	case "component":
+		return f.Component
+	case "sequence":
+		return f.Sequence
+	}
+
+	return ""
+}
+
+func (f *Fields) set(name string, value string) {
+	switch name {
+	case "version":
+		f.Version = value
+	case "major":
+		f.Major = value
+	case "minor":
+		f.Minor = value
+	case "patch":
+		f.Patch = value
+	case "prerelease":
+		f.Prerelease = value
+	case "build":
+		f.Build = value
+	case "component":
+		f.Component = value
+	case "sequence":
+		f.Sequence = value
+	}
+}
+
+// VersionText returns the version held in the fields, either from {version}
+// or assembled from {major}, {minor}, {patch}, {prerelease} and {build}.
+// Missing trailing parts are left off, e.g. 1.4 for a template without {patch}.
+func (f Fields) VersionText() string {
+	if f.Version != "" {
+		return f.Version
+	}
+
+	version := f.Major
+	if f.Minor != "" {
+		version += "." + f.Minor
+	}
+
+	if f.Patch != "" {
+		version += "." + f.Patch
+	}
+
+	if f.Prerelease != "" {
+		version += "-" + f.Prerelease
+	}
+
+	if f.Build != "" {
+		version += "+" + f.Build
+	}
+
+	return version
+}
@@ -0,0 +1,160 @@
+package pattern
+
+import (
+	"testing"
+)
+
+func TestTemplate_ParsesNames(t *testing.T) {
+	tests := []struct {
+		template string
+		name     string
+		expected string
+	}{
+		{"release/%v", "release/1.2.3", "1.2.3"},
+		{"release/{version}", "release/1.2.3-rc.1+build.5", "1.2.3-rc.1+build.5"},
+		{"rel-%v-stable", "rel-1.2.3-stable", "1.2.3"},
+		{"rel-%v-stable", "rel-1.2.3-rc.1-stable", "1.2.3-rc.1"},
+		{"100%-{version}", "100%-1.2.3", "1.2.3"},
+		{"release/{major}.{minor}", "release/1.4", "1.4"},
+		{"v{major}.{minor}.{patch}", "v1.4.2", "1.4.2"},
+		{"v{major}.{minor}.{patch}-{prerelease}", "v2.0.0-rc.1", "2.0.0-rc.1"},
+		{"v{major}.{minor}.{patch}-{prerelease}", "v2.0.0-rc.1+build.5", "2.0.0-rc.1+build.5"},
+		{"v{major}.{minor}.{patch}", "v2.0.0-rc.1+build.5", "2.0.0-rc.1+build.5"},
+		{"rel-{major}.{minor}.{patch}-stable", "rel-1.2.3-stable", "1.2.3"},
+		{"{component}/v{version}", "api/v1.2.3", "1.2.3"},
+	}
+
+	for _, test := range tests {
+		// Arrange
+		template := MustCompile(test.template)
+
+		// Act
+		fields, ok := template.Parse(test.name)
+
+		// Assert
+		if !ok {
+			t.Fatalf("%q.Parse(%q): expected a match", test.template, test.name)
+		}
+
+		if fields.VersionText() != test.expected {
+			t.Fatalf("%q.Parse(%q): expected %q, got %q", test.template, test.name, test.expected, fields.VersionText())
+		}
+	}
+}
+
+func TestTemplate_RejectsPartialMatches(t *testing.T) {
+	tests := []struct {
+		template string
+		name     string
+	}{
+		{"release/%v", "remotes/origin/release/1.2.3"},
+		{"release/%v", "release/1.2.3/hotfix"},
+		{"release/%v", "release/next"},
+		{"rel-%v-stable", "rel-1.2.3"},
+		{"release/{major}.{minor}", "release/1.4.2"},
+		{"release/{major}.{minor}", "release/1.4-rc.1"},
+		{"{major}.{minor}/{major}", "1.4/2"},
+	}
+
+	for _, test := range tests {
+		// Arrange
+		template := MustCompile(test.template)
+
+		// Act
+		_, ok := template.Parse(test.name)
+
+		// Assert
+		if ok {
+			t.Fatalf("%q.Parse(%q): expected no match", test.template, test.name)
+		}
+	}
+}
+
+func TestTemplate_RendersExactly(t *testing.T) {
+	// Arrange
+	fields := Fields{
+		Version:    "1.4.2-rc.1+build.5",
+		Major:      "1",
+		Minor:      "4",
+		Patch:      "2",
+		Prerelease: "rc.1",
+		Build:      "build.5",
+		Component:  "api",
+	}
+
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{"release/%v", "release/1.4.2-rc.1+build.5"},
+		{"rel-%v-stable", "rel-1.4.2-rc.1+build.5-stable"},
+		{"100% {version}", "100% 1.4.2-rc.1+build.5"},
+		{"{component}/release/{major}.{minor}", "api/release/1.4"},
+		{"v{major}.{minor}.{patch}", "v1.4.2-rc.1+build.5"},
+		{"v{major}.{minor}.{patch}-{prerelease}", "v1.4.2-rc.1+build.5"},
+		{"v{major}.{minor}.{patch}+{build}", "v1.4.2-rc.1+build.5"},
+		{"{build}/v{major}.{minor}.{patch}", "build.5/v1.4.2-rc.1"},
+	}
+
+	for _, test := range tests {
+		// Act
+		actual := MustCompile(test.template).Render(fields)
+
+		// Assert
+		if actual != test.expected {
+			t.Fatalf("%q.Render(): expected %q, got %q", test.template, test.expected, actual)
+		}
+	}
+}
+
+func TestCompile_RejectsInvalidPlaceholders(t *testing.T) {
+	for _, source := range []string{"release/{verison}", "release/{version"} {
+		// Act
+		_, err := Compile(source)
+
+		// Assert
+		if err == nil {
+			t.Fatalf("Compile(%q): expected an error", source)
+		}
+	}
+}
+
+func TestCompileTag_ParsesAndRendersSequence(t *testing.T) {
+	// Arrange
+	template, err := CompileTag("v{major}.{minor}.{patch}+%n")
+	if err != nil {
+		t.Fatalf("error compiling template: %v", err)
+	}
+
+	// Act
+	fields, ok := template.Parse("v1.2.0+3")
+
+	// Assert
+	if !ok {
+		t.Fatalf("expected a match")
+	}
+
+	if fields.VersionText() != "1.2.0" || fields.Sequence != "3" {
+		t.Fatalf("expected 1.2.0 and 3, got %q and %q", fields.VersionText(), fields.Sequence)
+	}
+
+	fields.Sequence = "4"
+	if actual := template.Render(fields); actual != "v1.2.0+4" {
+		t.Fatalf("expected v1.2.0+4, got %q", actual)
+	}
+}
+
+func TestCompile_TreatsSequenceAsLiteral(t *testing.T) {
+	// Act
+	_, err := Compile("release/{sequence}")
+	template := MustCompile("release/%v-%n")
+
+	// Assert
+	if err == nil {
+		t.Fatalf("expected an error for {sequence}")
+	}
+
+	if template.Has("sequence") {
+		t.Fatalf("expected %%n to be literal")
+	}
+}
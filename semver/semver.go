@@ -1,72 +1,112 @@
 package semver
 
 import (
-	"fmt"
-	"regexp"
-	"strconv"
 	"strings"
 )
 
 // Function to validate semver format
 func ValidateSemver(version string) bool {
-	re := regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z-.]+)?(\+[0-9A-Za-z-.]+)?$`)
-	return re.MatchString(version)
+	_, err := Parse(version)
+	return err == nil
 }
 
-// Function to increment version
-func IncrementVersion(version, part string) string {
-	parts := strings.Split(version, "-")
-	baseVersion := parts[0]
-	preRelease := ""
-	if len(parts) > 1 {
-		preRelease = parts[1]
+// CompareSemver is true if v1 has lower precedence than v2, as
+// Version.Compare decides. It is false if either is not a valid version.
+func CompareSemver(v1, v2 string) bool {
+	version1, err := Parse(v1)
+	if err != nil {
+		return false
+	}
+
+	version2, err := Parse(v2)
+	if err != nil {
+		return false
 	}
 
-	baseParts := strings.Split(baseVersion, ".")
-	major := baseParts[0]
-	minor := baseParts[1]
-	patch := baseParts[2]
-
-	switch part {
-	case "major":
-		major = fmt.Sprintf("%d", atoi(major)+1)
-		minor = "0"
-		patch = "0"
-		preRelease = ""
-	case "minor":
-		minor = fmt.Sprintf("%d", atoi(minor)+1)
-		patch = "0"
-		preRelease = ""
-	case "patch":
-		patch = fmt.Sprintf("%d", atoi(patch)+1)
-		preRelease = ""
+	return version1.LessThan(version2)
+}
+
+// compareOptionalPrerelease compares two possibly empty prereleases. A version
+// without a prerelease has higher precedence than one with.
+func compareOptionalPrerelease(pre1, pre2 string) int {
+	switch {
+	case pre1 == pre2:
+		return 0
+	case pre1 == "":
+		return 1
+	case pre2 == "":
+		return -1
 	}
 
-	if preRelease != "" {
-		return fmt.Sprintf("%s.%s.%s-%s", major, minor, patch, preRelease)
+	return comparePrerelease(pre1, pre2)
+}
+
+// comparePrerelease compares dot-separated prerelease identifiers field by
+// field. Numeric identifiers compare numerically and always have lower
+// precedence than alphanumeric ones, which compare lexically in ASCII order.
+// A larger set of fields wins when all preceding fields are equal.
+func comparePrerelease(pre1, pre2 string) int {
+	ids1 := strings.Split(pre1, ".")
+	ids2 := strings.Split(pre2, ".")
+
+	for i := 0; i < len(ids1) && i < len(ids2); i++ {
+		id1, id2 := ids1[i], ids2[i]
+		num1, num2 := isNumeric(id1), isNumeric(id2)
+
+		var c int
+		switch {
+		case num1 && num2:
+			c = compareNumeric(id1, id2)
+		case num1:
+			c = -1
+		case num2:
+			c = 1
+		default:
+			c = strings.Compare(id1, id2)
+		}
+
+		if c != 0 {
+			return c
+		}
 	}
-	return fmt.Sprintf("%s.%s.%s", major, minor, patch)
+
+	return compareInt(len(ids1), len(ids2))
 }
 
-// Helper function to convert string to int
-func atoi(s string) int {
-	i, _ := strconv.Atoi(s)
-	return i
+// compareNumeric compares two strings of digits without overflowing on
+// arbitrarily large values
+func compareNumeric(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+
+	if len(a) != len(b) {
+		return compareInt(len(a), len(b))
+	}
+
+	return strings.Compare(a, b)
 }
 
-// true if v1 is less than v2
-func CompareSemver(v1, v2 string) bool {
-	v1Parts := strings.Split(v1, ".")
-	v2Parts := strings.Split(v2, ".")
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
 
-	for i := 0; i < len(v1Parts) && i < len(v2Parts); i++ {
-		v1Num, _ := strconv.Atoi(v1Parts[i])
-		v2Num, _ := strconv.Atoi(v2Parts[i])
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
 
-		if v1Num != v2Num {
-			return v1Num < v2Num
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
 		}
 	}
 
-	return len(v1Parts) < len(v2Parts)
+	return true
 }
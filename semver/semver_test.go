@@ -0,0 +1,115 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestCompareSemver_FollowsSemverPrecedence(t *testing.T) {
+	// Each entry must have strictly lower precedence than the next, taken
+	// from the examples in the SemVer 2.0.0 specification
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"1.9.0",
+		"1.10.0",
+		"1.11.0",
+		"2.0.0",
+		"2.1.0",
+		"2.1.1",
+	}
+
+	for i := 0; i < len(ordered); i++ {
+		for j := 0; j < len(ordered); j++ {
+			// Act
+			actual := CompareSemver(ordered[i], ordered[j])
+
+			// Assert
+			expected := i < j
+			if actual != expected {
+				t.Fatalf("CompareSemver(%q, %q): expected %v, got %v", ordered[i], ordered[j], expected, actual)
+			}
+		}
+	}
+}
+
+func TestCompareSemver_ConformanceTable(t *testing.T) {
+	tests := []struct {
+		v1       string
+		v2       string
+		expected int
+	}{
+		// Version core
+		{"1.2.3", "1.2.3", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"1.2.3", "1.3.0", -1},
+		{"1.2.3", "2.0.0", -1},
+		{"1.3.9", "1.3.10", -1},
+		{"10.0.0", "9.99.99", 1},
+
+		// Prerelease versus release
+		{"1.2.3-rc.1", "1.2.3", -1},
+		{"1.2.3", "1.2.3-rc.1", 1},
+		{"1.2.3-rc.1", "1.2.2", 1},
+
+		// Prerelease identifiers
+		{"1.2.3-alpha", "1.2.3-beta", -1},
+		{"1.2.3-rc.1", "1.2.3-rc.2", -1},
+		{"1.2.3-rc.2", "1.2.3-rc.10", -1},
+		{"1.2.3-rc.1", "1.2.3-rc.1", 0},
+		{"1.2.3-1", "1.2.3-alpha", -1},
+		{"1.2.3-alpha", "1.2.3-1", 1},
+		{"1.2.3-alpha", "1.2.3-alpha.1", -1},
+		{"1.2.3-alpha.1", "1.2.3-alpha.1.0", -1},
+		{"1.2.3-Alpha", "1.2.3-alpha", -1},
+		{"1.2.3-alpha-1", "1.2.3-alpha-2", -1},
+		{"1.2.3-rc.1a", "1.2.3-rc.1b", -1},
+
+		// Build metadata is ignored
+		{"1.2.3+build.1", "1.2.3", 0},
+		{"1.2.3+build.1", "1.2.3+build.2", 0},
+		{"1.2.3-rc.1+build.5", "1.2.3-rc.1", 0},
+		{"1.2.3-rc.1+build.5", "1.2.3-rc.2+build.1", -1},
+		{"1.2.3+build.1", "1.2.4-alpha", -1},
+	}
+
+	for _, test := range tests {
+		// Act
+		actualLess := CompareSemver(test.v1, test.v2)
+		actualGreater := CompareSemver(test.v2, test.v1)
+
+		// Assert
+		if actualLess != (test.expected < 0) {
+			t.Fatalf("CompareSemver(%q, %q): expected %v, got %v", test.v1, test.v2, test.expected < 0, actualLess)
+		}
+
+		if actualGreater != (test.expected > 0) {
+			t.Fatalf("CompareSemver(%q, %q): expected %v, got %v", test.v2, test.v1, test.expected > 0, actualGreater)
+		}
+	}
+}
+
+func TestCompareSemver_InvalidVersionsAreNotLess(t *testing.T) {
+	tests := [][2]string{
+		{"1.2", "1.2.3"},
+		{"1.2.3", "v1.2.4"},
+		{"1.2.99999999999999999999", "1.2.100000000000000000000"},
+	}
+
+	for _, test := range tests {
+		// Act
+		actual := CompareSemver(test[0], test[1])
+
+		// Assert
+		if actual {
+			t.Fatalf("CompareSemver(%q, %q): expected false for an invalid version", test[0], test[1])
+		}
+	}
+}
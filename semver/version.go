@@ -0,0 +1,292 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidVersion = errors.New("invalid version format. please use semantic versioning (e.g., 1.0.0, 1.2.3-alpha, 2.0.0+build.1)")
+
+// Version is a parsed semantic version
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+	Build      string
+}
+
+// Parse parses a semantic version, returning ErrInvalidVersion if it does not
+// conform to SemVer 2.0.0
+func Parse(version string) (Version, error) {
+	rest, build, hasBuild := strings.Cut(version, "+")
+	core, prerelease, hasPrerelease := strings.Cut(rest, "-")
+
+	coreParts := strings.Split(core, ".")
+	if len(coreParts) != 3 {
+		return Version{}, ErrInvalidVersion
+	}
+
+	nums := make([]uint64, 3)
+	for i, part := range coreParts {
+		if !isNumeric(part) || (len(part) > 1 && part[0] == '0') {
+			return Version{}, ErrInvalidVersion
+		}
+
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return Version{}, ErrInvalidVersion
+		}
+
+		nums[i] = n
+	}
+
+	if hasPrerelease && !validIdentifiers(prerelease, true) {
+		return Version{}, ErrInvalidVersion
+	}
+
+	if hasBuild && !validIdentifiers(build, false) {
+		return Version{}, ErrInvalidVersion
+	}
+
+	return Version{
+		Major:      nums[0],
+		Minor:      nums[1],
+		Patch:      nums[2],
+		Prerelease: prerelease,
+		Build:      build,
+	}, nil
+}
+
+// ParseLine parses a release line of the given granularity (major, minor or
+// patch), e.g. 1.4 for a minor line, returning the first version of that line
+func ParseLine(line string, granularity string) (Version, error) {
+	switch granularity {
+	case "major":
+		return Parse(line + ".0.0")
+	case "minor":
+		return Parse(line + ".0")
+	}
+
+	return Parse(line)
+}
+
+// MustParse is like Parse but panics if the version is invalid
+func MustParse(version string) Version {
+	v, err := Parse(version)
+	if err != nil {
+		panic(fmt.Errorf("%w: %s", err, version))
+	}
+
+	return v
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+
+	return s
+}
+
+// Compare returns -1, 0 or 1 depending on whether v has lower, equal or
+// higher precedence than other. Build metadata is ignored.
+func (v Version) Compare(other Version) int {
+	if c := compareUint(v.Major, other.Major); c != 0 {
+		return c
+	}
+
+	if c := compareUint(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+
+	if c := compareUint(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+
+	return compareOptionalPrerelease(v.Prerelease, other.Prerelease)
+}
+
+// LessThan is true if v has lower precedence than other
+func (v Version) LessThan(other Version) bool {
+	return v.Compare(other) < 0
+}
+
+// IsZero is true for the 0.0.0 version
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// Increment returns the next version for the given part (major, minor or
+// patch). The prerelease and build metadata are dropped.
+func (v Version) Increment(part string) (Version, error) {
+	switch part {
+	case "major":
+		return Version{Major: v.Major + 1}, nil
+	case "minor":
+		return Version{Major: v.Major, Minor: v.Minor + 1}, nil
+	case "patch":
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}, nil
+	}
+
+	return Version{}, fmt.Errorf("unknown version part: %s", part)
+}
+
+// IncrementPrerelease returns the next prerelease version for the given part
+// (premajor, preminor, prepatch or prerelease) using the given prerelease
+// identifier, e.g. 1.2.3 premajor rc gives 2.0.0-rc.1. For prerelease, a
+// trailing numeric counter is bumped if the identifier matches, otherwise a
+// release is bumped as a prepatch.
+func (v Version) IncrementPrerelease(part string, preid string) (Version, error) {
+	if preid != "" && !validIdentifiers(preid, true) {
+		return Version{}, fmt.Errorf("invalid prerelease identifier: %s", preid)
+	}
+
+	var next Version
+	var err error
+	switch part {
+	case "premajor":
+		next, err = v.Increment("major")
+	case "preminor":
+		next, err = v.Increment("minor")
+	case "prepatch":
+		next, err = v.Increment("patch")
+	case "prerelease":
+		if v.Prerelease == "" {
+			return v.IncrementPrerelease("prepatch", preid)
+		}
+
+		next = Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+		if counter, ok := prereleaseCounter(v.Prerelease, preid); ok {
+			next.Prerelease = joinPrerelease(preid, counter+1)
+			return next, nil
+		}
+	default:
+		return Version{}, fmt.Errorf("unknown prerelease part: %s", part)
+	}
+
+	if err != nil {
+		return Version{}, err
+	}
+
+	next.Prerelease = joinPrerelease(preid, 1)
+	return next, nil
+}
+
+// Line returns the release line the version belongs to at the given
+// granularity (major, minor or patch), e.g. 1.4 for 1.4.2 at minor
+func (v Version) Line(granularity string) string {
+	switch granularity {
+	case "major":
+		return fmt.Sprintf("%d", v.Major)
+	case "minor":
+		return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+	}
+
+	return v.String()
+}
+
+// LineBase returns the first version of the release line the version belongs
+// to at the given granularity, e.g. 1.4.0 for 1.4.2 at minor
+func (v Version) LineBase(granularity string) Version {
+	switch granularity {
+	case "major":
+		return Version{Major: v.Major}
+	case "minor":
+		return Version{Major: v.Major, Minor: v.Minor}
+	}
+
+	return v
+}
+
+// IsPrerelease is true if the version has a prerelease component
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// Release returns the version without its prerelease or build metadata
+func (v Version) Release() Version {
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+}
+
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*v = parsed
+	return nil
+}
+
+// prereleaseCounter returns the trailing numeric counter of a prerelease if
+// the rest of it matches preid, e.g. rc.2 with preid rc gives 2
+func prereleaseCounter(prerelease string, preid string) (uint64, bool) {
+	prefix, counter := "", prerelease
+	if i := strings.LastIndex(prerelease, "."); i >= 0 {
+		prefix, counter = prerelease[:i], prerelease[i+1:]
+	}
+
+	if prefix != preid || !isNumeric(counter) {
+		return 0, false
+	}
+
+	n, err := strconv.ParseUint(counter, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+func joinPrerelease(preid string, counter uint64) string {
+	if preid == "" {
+		return strconv.FormatUint(counter, 10)
+	}
+
+	return preid + "." + strconv.FormatUint(counter, 10)
+}
+
+// validIdentifiers checks a dot-separated list of prerelease or build
+// identifiers. Numeric prerelease identifiers must not have leading zeros.
+func validIdentifiers(s string, prerelease bool) bool {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+
+		for _, r := range id {
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+				return false
+			}
+		}
+
+		if prerelease && isNumeric(id) && len(id) > 1 && id[0] == '0' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
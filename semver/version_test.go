@@ -0,0 +1,271 @@
+package semver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse_ParsesValidVersions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Version
+	}{
+		{"0.0.0", Version{}},
+		{"1.2.3", Version{Major: 1, Minor: 2, Patch: 3}},
+		{"10.20.30", Version{Major: 10, Minor: 20, Patch: 30}},
+		{"1.0.0-alpha", Version{Major: 1, Prerelease: "alpha"}},
+		{"1.0.0-rc.1", Version{Major: 1, Prerelease: "rc.1"}},
+		{"1.0.0-x-y-z.0", Version{Major: 1, Prerelease: "x-y-z.0"}},
+		{"1.0.0+build.1", Version{Major: 1, Build: "build.1"}},
+		{"1.0.0+001", Version{Major: 1, Build: "001"}},
+		{"1.0.0-beta.2+exp.sha.5114f85", Version{Major: 1, Prerelease: "beta.2", Build: "exp.sha.5114f85"}},
+	}
+
+	for _, test := range tests {
+		// Act
+		actual, err := Parse(test.input)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Parse(%q): unexpected error: %v", test.input, err)
+		}
+
+		if actual != test.expected {
+			t.Fatalf("Parse(%q): expected %+v, got %+v", test.input, test.expected, actual)
+		}
+
+		if actual.String() != test.input {
+			t.Fatalf("Parse(%q).String(): got %q", test.input, actual.String())
+		}
+	}
+}
+
+func TestParse_RejectsInvalidVersions(t *testing.T) {
+	inputs := []string{
+		"",
+		"1",
+		"1.2",
+		"1.2.3.4",
+		"v1.2.3",
+		"01.2.3",
+		"1.02.3",
+		"1.2.03",
+		"1.2.3-",
+		"1.2.3-01",
+		"1.2.3-rc..1",
+		"1.2.3+",
+		"1.2.3+build..1",
+		"1.2.3-rc_1",
+		"a.b.c",
+		"-1.2.3",
+		"1.2.99999999999999999999",
+		"invalid-version",
+	}
+
+	for _, input := range inputs {
+		// Act
+		_, err := Parse(input)
+
+		// Assert
+		if !errors.Is(err, ErrInvalidVersion) {
+			t.Fatalf("Parse(%q): expected ErrInvalidVersion, got %v", input, err)
+		}
+	}
+}
+
+func TestVersion_Increment(t *testing.T) {
+	tests := []struct {
+		input    string
+		part     string
+		expected string
+	}{
+		{"1.2.3", "major", "2.0.0"},
+		{"1.2.3", "minor", "1.3.0"},
+		{"1.2.3", "patch", "1.2.4"},
+		{"1.2.3-rc.1+build.1", "major", "2.0.0"},
+		{"1.2.3-rc.1+build.1", "patch", "1.2.4"},
+	}
+
+	for _, test := range tests {
+		// Act
+		actual, err := MustParse(test.input).Increment(test.part)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Increment(%q, %q): unexpected error: %v", test.input, test.part, err)
+		}
+
+		if actual.String() != test.expected {
+			t.Fatalf("Increment(%q, %q): expected %q, got %q", test.input, test.part, test.expected, actual.String())
+		}
+	}
+}
+
+func TestVersion_Increment_RejectsUnknownPart(t *testing.T) {
+	// Act
+	_, err := MustParse("1.2.3").Increment("build")
+
+	// Assert
+	if err == nil {
+		t.Fatalf("expected an error for an unknown version part")
+	}
+}
+
+func TestVersion_Compare_MatchesCompareSemver(t *testing.T) {
+	versions := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.0+build.1",
+		"1.0.1",
+		"2.0.0",
+	}
+
+	for _, v1 := range versions {
+		for _, v2 := range versions {
+			// Act
+			actual := MustParse(v1).LessThan(MustParse(v2))
+
+			// Assert
+			expected := CompareSemver(v1, v2)
+			if actual != expected {
+				t.Fatalf("%q.LessThan(%q): expected %v, got %v", v1, v2, expected, actual)
+			}
+		}
+	}
+}
+
+func TestVersion_MarshalsAsText(t *testing.T) {
+	// Arrange
+	type document struct {
+		Version Version `json:"version"`
+	}
+
+	// Act
+	data, err := json.Marshal(document{Version: MustParse("1.2.3-rc.1+build.5")})
+	if err != nil {
+		t.Fatalf("error marshalling: %v", err)
+	}
+
+	var decoded document
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatalf("error unmarshalling: %v", err)
+	}
+
+	// Assert
+	if string(data) != `{"version":"1.2.3-rc.1+build.5"}` {
+		t.Fatalf("unexpected JSON: %s", data)
+	}
+
+	if decoded.Version != MustParse("1.2.3-rc.1+build.5") {
+		t.Fatalf("expected round-tripped version, got %v", decoded.Version)
+	}
+}
+
+func TestVersion_UnmarshalText_RejectsInvalidVersions(t *testing.T) {
+	// Arrange
+	var v Version
+
+	// Act
+	err := v.UnmarshalText([]byte("1.2"))
+
+	// Assert
+	if !errors.Is(err, ErrInvalidVersion) {
+		t.Fatalf("expected ErrInvalidVersion, got %v", err)
+	}
+}
+
+func TestVersion_IncrementPrerelease(t *testing.T) {
+	tests := []struct {
+		input    string
+		part     string
+		preid    string
+		expected string
+	}{
+		{"1.2.3", "premajor", "rc", "2.0.0-rc.1"},
+		{"1.2.3", "preminor", "rc", "1.3.0-rc.1"},
+		{"1.2.3", "prepatch", "rc", "1.2.4-rc.1"},
+		{"1.2.3", "prerelease", "rc", "1.2.4-rc.1"},
+		{"2.0.0-rc.1", "prerelease", "rc", "2.0.0-rc.2"},
+		{"2.0.0-rc.9", "prerelease", "rc", "2.0.0-rc.10"},
+		{"2.0.0-beta.3", "prerelease", "rc", "2.0.0-rc.1"},
+		{"2.0.0-rc", "prerelease", "rc", "2.0.0-rc.1"},
+		{"2.0.0-rc.1+build.1", "prerelease", "rc", "2.0.0-rc.2"},
+		{"2.0.0-rc.1", "premajor", "rc", "3.0.0-rc.1"},
+		{"2.0.0-4", "prerelease", "", "2.0.0-5"},
+		{"0.0.0", "prerelease", "alpha", "0.0.1-alpha.1"},
+	}
+
+	for _, test := range tests {
+		// Act
+		actual, err := MustParse(test.input).IncrementPrerelease(test.part, test.preid)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("IncrementPrerelease(%q, %q, %q): unexpected error: %v", test.input, test.part, test.preid, err)
+		}
+
+		if actual.String() != test.expected {
+			t.Fatalf("IncrementPrerelease(%q, %q, %q): expected %q, got %q", test.input, test.part, test.preid, test.expected, actual.String())
+		}
+	}
+}
+
+func TestVersion_IncrementPrerelease_RejectsInvalidPreid(t *testing.T) {
+	// Act
+	_, err := MustParse("1.2.3").IncrementPrerelease("prerelease", "rc_1")
+
+	// Assert
+	if err == nil {
+		t.Fatalf("expected an error for an invalid prerelease identifier")
+	}
+}
+
+func TestVersion_Line(t *testing.T) {
+	tests := []struct {
+		input       string
+		granularity string
+		line        string
+		base        string
+	}{
+		{"1.4.2", "major", "1", "1.0.0"},
+		{"1.4.2", "minor", "1.4", "1.4.0"},
+		{"1.4.2", "patch", "1.4.2", "1.4.2"},
+		{"2.0.0-rc.1", "minor", "2.0", "2.0.0"},
+	}
+
+	for _, test := range tests {
+		// Act
+		version := MustParse(test.input)
+		line := version.Line(test.granularity)
+		base := version.LineBase(test.granularity)
+
+		// Assert
+		if line != test.line {
+			t.Fatalf("%q.Line(%q): expected %q, got %q", test.input, test.granularity, test.line, line)
+		}
+
+		if base.String() != test.base {
+			t.Fatalf("%q.LineBase(%q): expected %q, got %q", test.input, test.granularity, test.base, base.String())
+		}
+
+		parsed, err := ParseLine(line, test.granularity)
+		if err != nil || parsed != base {
+			t.Fatalf("ParseLine(%q, %q): expected %q, got %q (%v)", line, test.granularity, test.base, parsed, err)
+		}
+	}
+}
+
+func TestParseLine_RejectsWrongGranularity(t *testing.T) {
+	// Act
+	_, err := ParseLine("1.4.2", "minor")
+
+	// Assert
+	if !errors.Is(err, ErrInvalidVersion) {
+		t.Fatalf("expected ErrInvalidVersion, got %v", err)
+	}
+}
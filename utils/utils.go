@@ -17,6 +17,14 @@ func MapKeys[K comparable, V any](m map[K]V) []K {
 	return keys
 }
 
+func MapValues[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
 func FilterSlice[T any](slice []T, predicate func(T) bool) []T {
 	result := make([]T, 0, len(slice))
 	for _, v := range slice {
@@ -26,3 +34,23 @@ func FilterSlice[T any](slice []T, predicate func(T) bool) []T {
 	}
 	return result
 }
+
+// kindError is an error that also matches a kind, such as a sentinel error,
+// without changing its message
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// WithKind returns err such that errors.Is also matches kind
+func WithKind(kind error, err error) error {
+	return &kindError{kind: kind, err: err}
+}
@@ -0,0 +1,55 @@
+package versionfile
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+)
+
+// updateGo sets the value of a string constant, e.g. const Version = "1.2.3",
+// keeping raw strings raw
+func updateGo(contents []byte, name string, version string) ([]byte, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", contents, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, ident := range valueSpec.Names {
+				if ident.Name != name {
+					continue
+				}
+
+				if i >= len(valueSpec.Values) {
+					return nil, fmt.Errorf("constant %s has no value of its own", name)
+				}
+
+				literal, ok := valueSpec.Values[i].(*ast.BasicLit)
+				if !ok || literal.Kind != token.STRING {
+					return nil, fmt.Errorf("constant %s is not a string literal", name)
+				}
+
+				quoted := strconv.Quote(version)
+				if literal.Value[0] == '`' {
+					quoted = "`" + version + "`"
+				}
+
+				start := fileSet.Position(literal.Pos()).Offset
+				end := fileSet.Position(literal.End()).Offset
+				return splice(contents, start, end, quoted), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no constant %s", name)
+}
@@ -0,0 +1,61 @@
+package versionfile
+
+import "testing"
+
+func TestUpdateGo_ReplacesStringConstant(t *testing.T) {
+	tests := []struct {
+		contents string
+		name     string
+		expected string
+	}{
+		{
+			"package main\n\n// Version is set on release\nconst Version = \"1.3.0\" // release\n",
+			"Version",
+			"package main\n\n// Version is set on release\nconst Version = \"1.4.0\" // release\n",
+		},
+		{
+			"package main\n\nconst (\n\tName, Version = \"gitrel\", `1.3.0`\n)\n",
+			"Version",
+			"package main\n\nconst (\n\tName, Version = \"gitrel\", `1.4.0`\n)\n",
+		},
+		{
+			"package main\n\nvar AppVersion = \"0.0.0\"\n\nconst AppVersion2 string = \"1.3.0\"\n",
+			"AppVersion2",
+			"package main\n\nvar AppVersion = \"0.0.0\"\n\nconst AppVersion2 string = \"1.4.0\"\n",
+		},
+	}
+
+	for _, test := range tests {
+		// Act
+		updated, err := updateGo([]byte(test.contents), test.name, "1.4.0")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("updateGo(%q): error updating version: %v", test.name, err)
+		}
+
+		if string(updated) != test.expected {
+			t.Fatalf("updateGo(%q): expected %q, got %q", test.name, test.expected, updated)
+		}
+	}
+}
+
+func TestUpdateGo_FailsWithoutStringConstant(t *testing.T) {
+	tests := []string{
+		"package main\n\nvar Version = \"1.3.0\"\n",
+		"package main\n\nconst Version = 1\n",
+		"package main\n\nconst (\n\tVersion = iota\n\tOther\n)\n",
+		"package main\n\nconst (\n\tOther = \"1.3.0\"\n\tVersion\n)\n",
+		"not go\n",
+	}
+
+	for _, contents := range tests {
+		// Act
+		_, err := updateGo([]byte(contents), "Version", "1.4.0")
+
+		// Assert
+		if err == nil {
+			t.Fatalf("updateGo(%q): expected an error", contents)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package versionfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// updateJSON sets the string at a dotted key path, e.g. version, by splicing
+// it into the document so indentation and key order are kept
+func updateJSON(contents []byte, path string, version string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	start, end, err := findJSONString(decoder, contents, strings.Split(path, "."))
+	if err != nil {
+		return nil, err
+	}
+
+	if start < 0 {
+		return nil, fmt.Errorf("no value at %s", path)
+	}
+
+	quoted, err := json.Marshal(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return splice(contents, start, end, string(quoted)), nil
+}
+
+// findJSONString reads the value the decoder is at, returning where the string
+// at keys inside it starts and ends, or -1 if it has no such key
+func findJSONString(decoder *json.Decoder, contents []byte, keys []string) (int, int, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if token != json.Delim('{') {
+		return -1, -1, skipJSONValue(decoder, token)
+	}
+
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if key != keys[0] {
+			if err := skipJSONValue(decoder, nil); err != nil {
+				return 0, 0, err
+			}
+
+			continue
+		}
+
+		if len(keys) > 1 {
+			return findJSONString(decoder, contents, keys[1:])
+		}
+
+		// The value starts after the colon following the key
+		start := int(decoder.InputOffset())
+		for start < len(contents) && (contents[start] == ':' || isJSONSpace(contents[start])) {
+			start++
+		}
+
+		value, err := decoder.Token()
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if _, ok := value.(string); !ok {
+			return 0, 0, fmt.Errorf("%s is not a string", strings.Join(keys, "."))
+		}
+
+		return start, int(decoder.InputOffset()), nil
+	}
+
+	return -1, -1, nil
+}
+
+// skipJSONValue reads past a value, given its first token if it has already
+// been read
+func skipJSONValue(decoder *json.Decoder, token json.Token) error {
+	var err error
+	if token == nil {
+		token, err = decoder.Token()
+		if err != nil {
+			return err
+		}
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok || delim == '}' || delim == ']' {
+		return nil
+	}
+
+	for depth := 1; depth > 0; {
+		token, err = decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+
+	return nil
+}
+
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,62 @@
+package versionfile
+
+import "testing"
+
+func TestUpdateJSON_ReplacesVersionKeepingFormatting(t *testing.T) {
+	// Arrange
+	contents := "{\n  \"name\": \"gitrel\",\n  \"version\" :  \"1.3.0\",\n  \"scripts\": {\"version\": \"echo\"}\n}\n"
+
+	// Act
+	updated, err := updateJSON([]byte(contents), "version", "1.4.0")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error updating version: %v", err)
+	}
+
+	expected := "{\n  \"name\": \"gitrel\",\n  \"version\" :  \"1.4.0\",\n  \"scripts\": {\"version\": \"echo\"}\n}\n"
+	if string(updated) != expected {
+		t.Fatalf("expected %q, got %q", expected, updated)
+	}
+}
+
+func TestUpdateJSON_FollowsNestedPath(t *testing.T) {
+	// Arrange
+	contents := `{"version": "0.0.0", "tags": ["a", {"b": 1}], "app": {"meta": {"version": "1.3.0"}}}`
+
+	// Act
+	updated, err := updateJSON([]byte(contents), "app.meta.version", "1.4.0-rc.1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error updating version: %v", err)
+	}
+
+	expected := `{"version": "0.0.0", "tags": ["a", {"b": 1}], "app": {"meta": {"version": "1.4.0-rc.1"}}}`
+	if string(updated) != expected {
+		t.Fatalf("expected %q, got %q", expected, updated)
+	}
+}
+
+func TestUpdateJSON_FailsWithoutStringAtPath(t *testing.T) {
+	tests := []struct {
+		contents string
+		path     string
+	}{
+		{`{"name": "gitrel"}`, "version"},
+		{`{"version": 1}`, "version"},
+		{`{"app": "1.3.0"}`, "app.version"},
+		{`["1.3.0"]`, "version"},
+		{`{"version": `, "version"},
+	}
+
+	for _, test := range tests {
+		// Act
+		_, err := updateJSON([]byte(test.contents), test.path, "1.4.0")
+
+		// Assert
+		if err == nil {
+			t.Fatalf("updateJSON(%q, %q): expected an error", test.contents, test.path)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package versionfile
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// updateRegex replaces the first group of every match of a pattern with the
+// version, or the whole match if the pattern has no groups
+func updateRegex(contents []byte, expression string, version string) ([]byte, error) {
+	pattern, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := pattern.FindAllSubmatchIndex(contents, -1)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no match for %s", expression)
+	}
+
+	group := 0
+	if pattern.NumSubexp() > 0 {
+		group = 1
+	}
+
+	// Replace from the end so earlier offsets stay valid
+	updated := contents
+	for i := len(matches) - 1; i >= 0; i-- {
+		start, end := matches[i][2*group], matches[i][2*group+1]
+		if start < 0 {
+			continue
+		}
+
+		updated = splice(updated, start, end, version)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,45 @@
+package versionfile
+
+import "testing"
+
+func TestUpdateRegex_ReplacesFirstGroupOfEveryMatch(t *testing.T) {
+	// Arrange
+	contents := "# gitrel\n\nInstall v1.3.0:\n\n    go install gitrel@v1.3.0\n"
+
+	// Act
+	updated, err := updateRegex([]byte(contents), `gitrel@v(\d+\.\d+\.\d+)|Install v([0-9.]+)`, "1.4.0")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error updating version: %v", err)
+	}
+
+	expected := "# gitrel\n\nInstall v1.3.0:\n\n    go install gitrel@v1.4.0\n"
+	if string(updated) != expected {
+		t.Fatalf("expected %q, got %q", expected, updated)
+	}
+}
+
+func TestUpdateRegex_ReplacesWholeMatchWithoutGroups(t *testing.T) {
+	// Act
+	updated, err := updateRegex([]byte("1.3.0\n"), `(?m)^\S+$`, "1.4.0")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error updating version: %v", err)
+	}
+
+	if string(updated) != "1.4.0\n" {
+		t.Fatalf("expected %q, got %q", "1.4.0\n", updated)
+	}
+}
+
+func TestUpdateRegex_FailsWithoutMatch(t *testing.T) {
+	// Act
+	_, err := updateRegex([]byte("no version here\n"), `version=(\S+)`, "1.4.0")
+
+	// Assert
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -0,0 +1,63 @@
+package versionfile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// updateTOML sets the string at a dotted key path, e.g. project.version,
+// counting the table a key is in as part of its path. The string is replaced
+// where it is in the file, keeping its quotes.
+func updateTOML(contents []byte, path string, version string) ([]byte, error) {
+	parser := unstable.Parser{}
+	parser.Reset(contents)
+
+	table := []string{}
+	for parser.NextExpression() {
+		expression := parser.Expression()
+		switch expression.Kind {
+		case unstable.Table:
+			table = tomlKey(expression)
+		case unstable.ArrayTable:
+			// Keys in arrays of tables can't be addressed by a path
+			table = nil
+		case unstable.KeyValue:
+			if table == nil || strings.Join(append(table, tomlKey(expression)...), ".") != path {
+				continue
+			}
+
+			value := expression.Value()
+			if value.Kind != unstable.String {
+				return nil, fmt.Errorf("%s is not a string", path)
+			}
+
+			raw := parser.Raw(value.Raw)
+			if len(raw) >= 3 && (string(raw[:3]) == `"""` || string(raw[:3]) == "'''") {
+				return nil, fmt.Errorf("%s is not a single line string", path)
+			}
+
+			start := int(value.Raw.Offset)
+			end := start + int(value.Raw.Length)
+			quote := string(raw[0])
+			return splice(contents, start, end, quote+version+quote), nil
+		}
+	}
+
+	if err := parser.Error(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("no value at %s", path)
+}
+
+// tomlKey returns the parts of the key of a table or key/value expression
+func tomlKey(expression *unstable.Node) []string {
+	key := []string{}
+	for parts := expression.Key(); parts.Next(); {
+		key = append(key, string(parts.Node().Data))
+	}
+
+	return key
+}
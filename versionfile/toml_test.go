@@ -0,0 +1,71 @@
+package versionfile
+
+import "testing"
+
+func TestUpdateTOML_ReplacesVersionInTable(t *testing.T) {
+	// Arrange
+	contents := "version = \"0.0.0\"\n\n[tool.poetry]\nname = \"gitrel\"\n\n[project]\n# Bumped on release\nversion   = '1.3.0' # project\n"
+
+	// Act
+	updated, err := updateTOML([]byte(contents), "project.version", "1.4.0")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error updating version: %v", err)
+	}
+
+	expected := "version = \"0.0.0\"\n\n[tool.poetry]\nname = \"gitrel\"\n\n[project]\n# Bumped on release\nversion   = '1.4.0' # project\n"
+	if string(updated) != expected {
+		t.Fatalf("expected %q, got %q", expected, updated)
+	}
+}
+
+func TestUpdateTOML_MatchesDottedKeys(t *testing.T) {
+	tests := []struct {
+		contents string
+		path     string
+		expected string
+	}{
+		{"version = \"1.3.0\"\n", "version", "version = \"1.4.0\"\n"},
+		{"package.version = \"1.3.0\"\n", "package.version", "package.version = \"1.4.0\"\n"},
+		{"[tool]\npoetry.version = \"1.3.0\"\n", "tool.poetry.version", "[tool]\npoetry.version = \"1.4.0\"\n"},
+	}
+
+	for _, test := range tests {
+		// Act
+		updated, err := updateTOML([]byte(test.contents), test.path, "1.4.0")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("updateTOML(%q): error updating version: %v", test.path, err)
+		}
+
+		if string(updated) != test.expected {
+			t.Fatalf("updateTOML(%q): expected %q, got %q", test.path, test.expected, updated)
+		}
+	}
+}
+
+func TestUpdateTOML_FailsWithoutStringAtPath(t *testing.T) {
+	tests := []struct {
+		contents string
+		path     string
+	}{
+		{"name = \"gitrel\"\n", "version"},
+		{"version = 1\n", "version"},
+		{"[project]\nversion = \"1.3.0\"\n", "version"},
+		{"[[bin]]\nversion = \"1.3.0\"\n", "version"},
+		{"version = \"\"\"\n1.3.0\"\"\"\n", "version"},
+		{"version = \n", "version"},
+	}
+
+	for _, test := range tests {
+		// Act
+		_, err := updateTOML([]byte(test.contents), test.path, "1.4.0")
+
+		// Assert
+		if err == nil {
+			t.Fatalf("updateTOML(%q, %q): expected an error", test.contents, test.path)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package versionfile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Formats of the files holding a version
+const (
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatTOML  = "toml"
+	FormatRegex = "regex"
+	FormatGo    = "go"
+)
+
+// File is a project file holding a version string, e.g. package.json, and
+// where in it the version is. The expression is a dotted key path for json,
+// yaml and toml files, a pattern whose first group is the version for regex
+// files and the name of a string constant for Go files.
+type File struct {
+	Path       string
+	Format     string
+	Expression string
+}
+
+// defaultExpressions are used when a file is listed without an expression
+var defaultExpressions = map[string]string{
+	FormatJSON: "version",
+	FormatYAML: "version",
+	FormatTOML: "version",
+	FormatGo:   "Version",
+}
+
+// ParseFiles reads a list of version files separated by semicolons, each as
+// path:format[:expression], e.g.
+// package.json:json; Chart.yaml:yaml:appVersion; VERSION:regex:^(.+)$
+// Everything after the second colon is the expression, so patterns may
+// contain colons but not semicolons.
+func ParseFiles(files string) ([]File, error) {
+	parsed := []File{}
+	for _, entry := range strings.Split(files, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) < 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid version file '%s', expected path:format[:expression]", entry)
+		}
+
+		file := File{Path: parts[0], Format: parts[1]}
+		if len(parts) == 3 {
+			file.Expression = parts[2]
+		}
+
+		switch file.Format {
+		case FormatJSON, FormatYAML, FormatTOML, FormatGo:
+			if file.Expression == "" {
+				file.Expression = defaultExpressions[file.Format]
+			}
+		case FormatRegex:
+			if file.Expression == "" {
+				return nil, fmt.Errorf("version file %s needs a pattern", file.Path)
+			}
+
+			if _, err := regexp.Compile(file.Expression); err != nil {
+				return nil, fmt.Errorf("invalid pattern for version file %s: %w", file.Path, err)
+			}
+		default:
+			return nil, fmt.Errorf("invalid format '%s' for version file %s. please use one of: json, yaml, toml, regex, go", file.Format, file.Path)
+		}
+
+		parsed = append(parsed, file)
+	}
+
+	return parsed, nil
+}
+
+// String formats the file the way ParseFiles reads it
+func (f File) String() string {
+	return f.Path + ":" + f.Format + ":" + f.Expression
+}
+
+// Update returns the contents of the file with its version set. Everything
+// else in the file is left as it was, including formatting and comments.
+func (f File) Update(contents []byte, version string) ([]byte, error) {
+	var updated []byte
+	var err error
+	switch f.Format {
+	case FormatJSON:
+		updated, err = updateJSON(contents, f.Expression, version)
+	case FormatYAML:
+		updated, err = updateYAML(contents, f.Expression, version)
+	case FormatTOML:
+		updated, err = updateTOML(contents, f.Expression, version)
+	case FormatRegex:
+		updated, err = updateRegex(contents, f.Expression, version)
+	case FormatGo:
+		updated, err = updateGo(contents, f.Expression, version)
+	default:
+		err = fmt.Errorf("unknown format '%s'", f.Format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error updating version in %s: %w", f.Path, err)
+	}
+
+	return updated, nil
+}
+
+// splice replaces the bytes from start to end
+func splice(contents []byte, start int, end int, replacement string) []byte {
+	updated := make([]byte, 0, len(contents)-(end-start)+len(replacement))
+	updated = append(updated, contents[:start]...)
+	updated = append(updated, replacement...)
+	return append(updated, contents[end:]...)
+}
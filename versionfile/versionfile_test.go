@@ -0,0 +1,59 @@
+package versionfile
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseFiles_ReadsEntriesWithDefaultExpressions(t *testing.T) {
+	// Act
+	files, err := ParseFiles("package.json:json; Chart.yaml:yaml:appVersion ;version.go:go;VERSION:regex:^v?(\\d+\\.\\d+\\.\\d+):$;")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("error parsing version files: %v", err)
+	}
+
+	expected := []File{
+		{Path: "package.json", Format: FormatJSON, Expression: "version"},
+		{Path: "Chart.yaml", Format: FormatYAML, Expression: "appVersion"},
+		{Path: "version.go", Format: FormatGo, Expression: "Version"},
+		{Path: "VERSION", Format: FormatRegex, Expression: "^v?(\\d+\\.\\d+\\.\\d+):$"},
+	}
+	if !slices.Equal(files, expected) {
+		t.Fatalf("expected %v, got %v", expected, files)
+	}
+}
+
+func TestParseFiles_RejectsInvalidEntries(t *testing.T) {
+	entries := []string{
+		"package.json",
+		":json",
+		"setup.cfg:ini:version",
+		"VERSION:regex",
+		"VERSION:regex:(unclosed",
+	}
+
+	for _, entry := range entries {
+		// Act
+		_, err := ParseFiles(entry)
+
+		// Assert
+		if err == nil {
+			t.Fatalf("ParseFiles(%q): expected an error", entry)
+		}
+	}
+}
+
+func TestUpdate_NamesFileInErrors(t *testing.T) {
+	// Arrange
+	file := File{Path: "package.json", Format: FormatJSON, Expression: "version"}
+
+	// Act
+	_, err := file.Update([]byte(`{"name": "gitrel"}`), "1.4.0")
+
+	// Assert
+	if err == nil || err.Error() != "error updating version in package.json: no value at version" {
+		t.Fatalf("expected error naming the file, got %v", err)
+	}
+}
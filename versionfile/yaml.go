@@ -0,0 +1,98 @@
+package versionfile
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// updateYAML sets the scalar at a dotted key path, e.g. appVersion, keeping
+// its quoting. The scalar is replaced where it is in the file, so comments
+// and layout are kept.
+func updateYAML(contents []byte, path string, version string) ([]byte, error) {
+	document := yaml.Node{}
+	err := yaml.Unmarshal(contents, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(document.Content) == 0 {
+		return nil, fmt.Errorf("no value at %s", path)
+	}
+
+	node := document.Content[0]
+	for _, key := range strings.Split(path, ".") {
+		node = yamlMapValue(node, key)
+		if node == nil {
+			return nil, fmt.Errorf("no value at %s", path)
+		}
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return nil, fmt.Errorf("%s is not a scalar", path)
+	}
+
+	start := yamlOffset(contents, node.Line, node.Column)
+	if start < 0 {
+		return nil, fmt.Errorf("error finding %s", path)
+	}
+
+	switch node.Style {
+	case 0:
+		return splice(contents, start, start+len(node.Value), version), nil
+	case yaml.DoubleQuotedStyle, yaml.SingleQuotedStyle:
+		quote := contents[start : start+1]
+		end := bytes.Index(contents[start+1:], quote)
+		if end < 0 {
+			return nil, fmt.Errorf("error finding the end of %s", path)
+		}
+
+		return splice(contents, start, start+end+2, string(quote)+version+string(quote)), nil
+	default:
+		return nil, fmt.Errorf("%s is not a single line scalar", path)
+	}
+}
+
+// yamlMapValue returns the value of a key in a mapping node, or nil if the
+// node isn't a mapping or hasn't the key
+func yamlMapValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// yamlOffset converts a 1-based line and column, counted in characters, into
+// a byte offset
+func yamlOffset(contents []byte, line int, column int) int {
+	offset := 0
+	for i := 1; i < line; i++ {
+		next := bytes.IndexByte(contents[offset:], '\n')
+		if next < 0 {
+			return -1
+		}
+
+		offset += next + 1
+	}
+
+	for i := 1; i < column; i++ {
+		if offset >= len(contents) {
+			return -1
+		}
+
+		_, size := utf8.DecodeRune(contents[offset:])
+		offset += size
+	}
+
+	return offset
+}
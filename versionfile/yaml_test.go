@@ -0,0 +1,68 @@
+package versionfile
+
+import "testing"
+
+func TestUpdateYAML_ReplacesVersionKeepingCommentsAndQuotes(t *testing.T) {
+	tests := []struct {
+		contents string
+		path     string
+		expected string
+	}{
+		{
+			"apiVersion: v2\n# Bumped on release\nversion: 1.3.0 # chart\nappVersion: \"1.3.0\"\n",
+			"appVersion",
+			"apiVersion: v2\n# Bumped on release\nversion: 1.3.0 # chart\nappVersion: \"1.4.0\"\n",
+		},
+		{
+			"version: 1.3.0 # chart\n",
+			"version",
+			"version: 1.4.0 # chart\n",
+		},
+		{
+			"image:\n  repository: gitrel\n  tag: 'v1.3.0'\n",
+			"image.tag",
+			"image:\n  repository: gitrel\n  tag: '1.4.0'\n",
+		},
+		{
+			"name: \"ünïcode\"\nmeta: {app: gitrel, version: 1.3.0}\n",
+			"meta.version",
+			"name: \"ünïcode\"\nmeta: {app: gitrel, version: 1.4.0}\n",
+		},
+	}
+
+	for _, test := range tests {
+		// Act
+		updated, err := updateYAML([]byte(test.contents), test.path, "1.4.0")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("updateYAML(%q): error updating version: %v", test.path, err)
+		}
+
+		if string(updated) != test.expected {
+			t.Fatalf("updateYAML(%q): expected %q, got %q", test.path, test.expected, updated)
+		}
+	}
+}
+
+func TestUpdateYAML_FailsWithoutScalarAtPath(t *testing.T) {
+	tests := []struct {
+		contents string
+		path     string
+	}{
+		{"name: gitrel\n", "version"},
+		{"version:\n  major: 1\n", "version"},
+		{"version: |\n  1.3.0\n", "version"},
+		{"", "version"},
+	}
+
+	for _, test := range tests {
+		// Act
+		_, err := updateYAML([]byte(test.contents), test.path, "1.4.0")
+
+		// Assert
+		if err == nil {
+			t.Fatalf("updateYAML(%q, %q): expected an error", test.contents, test.path)
+		}
+	}
+}